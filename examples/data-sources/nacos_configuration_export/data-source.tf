@@ -0,0 +1,9 @@
+data "nacos_configuration_export" "example" {
+  namespace_id = "some-value"
+  group        = "some-value"
+  output_path  = "${path.module}/backup.zip"
+}
+
+output "backup_sha256" {
+  value = data.nacos_configuration_export.example.sha256
+}
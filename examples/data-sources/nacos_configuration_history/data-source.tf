@@ -0,0 +1,8 @@
+data "nacos_configuration_history" "example" {
+  namespace_id    = "some-value"
+  data_id         = "some-value"
+  group           = "some-value"
+  op_type         = "U"
+  limit           = 10
+  include_content = true
+}
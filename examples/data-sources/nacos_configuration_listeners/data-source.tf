@@ -0,0 +1,17 @@
+data "nacos_configuration_listeners" "example" {
+  data_id = "application.properties"
+  group   = "DEFAULT_GROUP"
+}
+
+data "nacos_configuration" "example" {
+  data_id = "application.properties"
+  group   = "DEFAULT_GROUP"
+}
+
+# Clients which have not received the current content yet.
+output "stale_listeners" {
+  value = [
+    for l in data.nacos_configuration_listeners.example.listeners : l.ip
+    if l.md5 != data.nacos_configuration.example.md5
+  ]
+}
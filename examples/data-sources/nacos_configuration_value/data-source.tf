@@ -0,0 +1,14 @@
+data "nacos_configuration_value" "db_host" {
+  data_id = "application.yaml"
+  group   = "DEFAULT_GROUP"
+  key     = "spring.datasource.host"
+}
+
+output "db_host" {
+  value = data.nacos_configuration_value.db_host.value
+}
+
+# The whole parsed document is available as well.
+output "db_port" {
+  value = data.nacos_configuration_value.db_host.document.spring.datasource.port
+}
@@ -1,3 +1,11 @@
 data "nacos_configurations" "example" {
   namespace_id = "some-value"
 }
+
+# All gateway configurations tagged "payments".
+data "nacos_configurations" "payments_gateways" {
+  namespace_id = "some-value"
+  search_mode  = "blur"
+  data_id      = "*-gateway.yaml"
+  tags         = ["payments"]
+}
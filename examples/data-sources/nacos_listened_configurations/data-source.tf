@@ -0,0 +1,7 @@
+data "nacos_listened_configurations" "example" {
+  ip = "10.0.0.12"
+}
+
+output "listened_ids" {
+  value = data.nacos_listened_configurations.example.configurations[*].id
+}
@@ -12,3 +12,15 @@ EOF
   tags         = ["terraform"]
   application  = "application-name"
 }
+
+# Updates only complete once the listening clients hold the new content.
+resource "nacos_configuration" "gateway" {
+  data_id = "gateway.properties"
+  content = "routes.payments=lb://payments"
+  type    = "properties"
+
+  wait_for_propagation {
+    timeout      = "2m"
+    min_fraction = 0.9
+  }
+}
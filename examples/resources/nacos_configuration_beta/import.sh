@@ -0,0 +1 @@
+terraform import nacos_configuration_beta.example "namespace_id:group:data_id"
\ No newline at end of file
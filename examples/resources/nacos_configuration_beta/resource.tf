@@ -0,0 +1,14 @@
+resource "nacos_configuration" "example" {
+  data_id = "some-data-id"
+  content = "feature.enabled=false"
+  type    = "properties"
+}
+
+# Canary the new content on two clients. To promote it, set the content on
+# nacos_configuration.example and remove this resource.
+resource "nacos_configuration_beta" "example" {
+  data_id  = nacos_configuration.example.data_id
+  content  = "feature.enabled=true"
+  type     = "properties"
+  beta_ips = ["10.0.0.1", "10.0.0.2"]
+}
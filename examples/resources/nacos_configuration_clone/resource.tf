@@ -0,0 +1,11 @@
+resource "nacos_namespace" "qa" {
+  namespace_id = "qa"
+  name         = "qa"
+}
+
+resource "nacos_configuration_clone" "example" {
+  source_namespace_id = "staging"
+  target_namespace_id = nacos_namespace.qa.namespace_id
+  policy              = "SKIP"
+  delete_on_destroy   = true
+}
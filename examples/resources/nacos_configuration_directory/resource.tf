@@ -0,0 +1,8 @@
+# configs/
+#   DEFAULT_GROUP/application.yaml
+#   payments/gateway.properties
+resource "nacos_configuration_directory" "example" {
+  path         = "${path.module}/configs"
+  namespace_id = "some-namespace"
+  prune        = true
+}
@@ -0,0 +1 @@
+terraform import nacos_configuration_gray.example "namespace_id:group:data_id:gray_name"
\ No newline at end of file
@@ -0,0 +1,8 @@
+# Deliver the gray content to clients started with the label
+# nacos.config.gray.label=v2. Requires Nacos 3.x.
+resource "nacos_configuration_gray" "example" {
+  data_id         = "some-data-id"
+  rule_expression = "v2"
+  content         = "feature.enabled=true"
+  type            = "properties"
+}
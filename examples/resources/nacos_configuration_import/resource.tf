@@ -0,0 +1,9 @@
+data "nacos_configuration_export" "source" {
+  namespace_id = "source-namespace"
+}
+
+resource "nacos_configuration_import" "example" {
+  namespace_id   = "target-namespace"
+  content_base64 = data.nacos_configuration_export.source.content_base64
+  policy         = "SKIP"
+}
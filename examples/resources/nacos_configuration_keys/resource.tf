@@ -0,0 +1,17 @@
+# The configuration is shared by several teams, each one owns some keys.
+resource "nacos_configuration_keys" "payments" {
+  data_id = "application.properties"
+  keys = {
+    "payments.endpoint" = "https://payments.example.com"
+    "payments.timeout"  = "30s"
+  }
+}
+
+# Keys of yaml and json configurations are dotted paths, values are JSON encoded.
+resource "nacos_configuration_keys" "gateway" {
+  data_id = "gateway.yaml"
+  keys = {
+    "server.port"     = jsonencode(8080)
+    "routes.payments" = jsonencode({ uri = "lb://payments", strip_prefix = true })
+  }
+}
@@ -0,0 +1 @@
+terraform import nacos_configuration_rollback.example "namespace_id:group:data_id:nid"
\ No newline at end of file
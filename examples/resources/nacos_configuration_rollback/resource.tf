@@ -0,0 +1,8 @@
+# Look up the nid with the nacos_configuration_history data source and pin it.
+# Do not select the newest revision here, the rollback writes a new revision
+# which changes the selection and rolls the configuration back again on every
+# apply.
+resource "nacos_configuration_rollback" "example" {
+  configuration_id = "some-namespace-id:DEFAULT_GROUP:some-data-id"
+  nid              = 1024
+}
@@ -0,0 +1 @@
+terraform import nacos_configuration_tag_variant.example "namespace_id:group:data_id:tag"
\ No newline at end of file
@@ -0,0 +1,13 @@
+resource "nacos_configuration" "example" {
+  data_id = "some-data-id"
+  content = "endpoint=https://api.example.com"
+  type    = "properties"
+}
+
+# Clients requesting the configuration with tag "eu-west" receive this content.
+resource "nacos_configuration_tag_variant" "eu_west" {
+  data_id = nacos_configuration.example.data_id
+  tag     = "eu-west"
+  content = "endpoint=https://eu-west.api.example.com"
+  type    = "properties"
+}
@@ -0,0 +1,20 @@
+resource "nacos_configurations" "example" {
+  namespace_id = "some-namespace"
+  group        = "feature-flags"
+  items = {
+    for name, enabled in var.feature_flags : "${name}.properties" => {
+      content     = "enabled=${enabled}"
+      type        = "properties"
+      description = "Feature flag ${name}"
+      tags        = ["feature-flag"]
+    }
+  }
+}
+
+variable "feature_flags" {
+  type = map(bool)
+  default = {
+    checkout-v2 = true
+    dark-mode   = false
+  }
+}
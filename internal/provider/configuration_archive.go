@@ -0,0 +1,225 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/joelee2012/go-nacos"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ExportMetadataV2 is the metadata format of the Nacos 2.x console export, `.metadata.yml`.
+	ExportMetadataV2 = "v2"
+	// ExportMetadataV1 is the metadata format of the Nacos 1.x console export, `.meta.yml`.
+	ExportMetadataV1 = "v1"
+)
+
+const (
+	metadataV2Name = ".metadata.yml"
+	metadataV1Name = ".meta.yml"
+)
+
+// BuildConfigurationArchive returns a ZIP archive of the configurations in
+// the layout of the Nacos console export: the content of each configuration
+// is stored as `<group>/<data_id>`, and the metadata in `.metadata.yml` (v2)
+// or `.meta.yml` (v1). Entries are sorted and carry no timestamps, so the
+// same configurations always produce the same archive.
+func BuildConfigurationArchive(configs []*nacos.Configuration, metadataFormat string) ([]byte, error) {
+	sorted := make([]*nacos.Configuration, len(configs))
+	copy(sorted, configs)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].GetGroup() != sorted[j].GetGroup() {
+			return sorted[i].GetGroup() < sorted[j].GetGroup()
+		}
+		return sorted[i].DataID < sorted[j].DataID
+	})
+
+	var metadata, metadataName string
+	switch metadataFormat {
+	case ExportMetadataV1:
+		metadataName, metadata = metadataV1Name, exportMetadataV1(sorted)
+	case ExportMetadataV2, "":
+		metadataName, metadata = metadataV2Name, exportMetadataV2(sorted)
+	default:
+		return nil, fmt.Errorf("unsupported metadata format %q", metadataFormat)
+	}
+
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	write := func(name, content string) error {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+		if err != nil {
+			return err
+		}
+		_, err = f.Write([]byte(content))
+		return err
+	}
+	for _, cfg := range sorted {
+		if err := write(cfg.GetGroup()+"/"+cfg.DataID, cfg.Content); err != nil {
+			return nil, err
+		}
+	}
+	if err := write(metadataName, metadata); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// exportMetadataV2 returns the `.metadata.yml` of the configurations.
+func exportMetadataV2(configs []*nacos.Configuration) string {
+	var b strings.Builder
+	b.WriteString("metadata:\n")
+	for _, cfg := range configs {
+		fmt.Fprintf(&b, "- dataId: %s\n", yamlQuote(cfg.DataID))
+		fmt.Fprintf(&b, "  group: %s\n", yamlQuote(cfg.GetGroup()))
+		fmt.Fprintf(&b, "  type: %s\n", yamlQuote(cfg.Type))
+		fmt.Fprintf(&b, "  appName: %s\n", yamlQuote(cfg.Application))
+		fmt.Fprintf(&b, "  desc: %s\n", yamlQuote(cfg.Description))
+	}
+	return b.String()
+}
+
+// exportMetadataV1 returns the `.meta.yml` of the configurations, which only
+// records the application of each configuration.
+func exportMetadataV1(configs []*nacos.Configuration) string {
+	var b strings.Builder
+	for _, cfg := range configs {
+		if cfg.Application == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "%s=%s\r\n", metadataV1Key(cfg.GetGroup(), cfg.DataID), cfg.Application)
+	}
+	return b.String()
+}
+
+// metadataV1Key returns the `.meta.yml` key of a configuration, dots in the
+// data id are replaced with `~` as the Nacos 1.x console does.
+func metadataV1Key(group, dataID string) string {
+	return group + "." + strings.ReplaceAll(dataID, ".", "~") + ".app"
+}
+
+// yamlQuote returns s as a double-quoted YAML scalar, JSON strings are valid
+// YAML double-quoted scalars.
+func yamlQuote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// archiveMetadataV2 is the content of `.metadata.yml`.
+type archiveMetadataV2 struct {
+	Metadata []struct {
+		DataID  string `yaml:"dataId"`
+		Group   string `yaml:"group"`
+		Type    string `yaml:"type"`
+		AppName string `yaml:"appName"`
+		Desc    string `yaml:"desc"`
+	} `yaml:"metadata"`
+}
+
+// ParseConfigurationArchive returns the configurations of a ZIP archive in
+// the layout of the Nacos console export, sorted by group and data id. The
+// type of configurations without `.metadata.yml` is derived from the data id
+// extension as the Nacos console does.
+func ParseConfigurationArchive(archive []byte) ([]*nacos.Configuration, error) {
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open archive: %w", err)
+	}
+	var configs []*nacos.Configuration
+	var metadataV2 *archiveMetadataV2
+	metadataV1 := make(map[string]string)
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		content, err := readArchiveFile(f)
+		if err != nil {
+			return nil, err
+		}
+		switch f.Name {
+		case metadataV2Name:
+			metadataV2 = new(archiveMetadataV2)
+			if err := yaml.Unmarshal([]byte(content), metadataV2); err != nil {
+				return nil, fmt.Errorf("unable to parse %s: %w", metadataV2Name, err)
+			}
+			continue
+		case metadataV1Name:
+			scanner := bufio.NewScanner(strings.NewReader(content))
+			for scanner.Scan() {
+				if key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "="); ok {
+					metadataV1[key] = value
+				}
+			}
+			continue
+		}
+		group, dataID, ok := strings.Cut(f.Name, "/")
+		if !ok || group == "" || dataID == "" || strings.Contains(dataID, "/") {
+			return nil, fmt.Errorf("unexpected file %q in archive, expected <group>/<data_id>", f.Name)
+		}
+		configs = append(configs, &nacos.Configuration{DataID: dataID, Group: group, Content: content})
+	}
+
+	for _, cfg := range configs {
+		cfg.Type = configurationTypeOf(cfg.DataID)
+		cfg.Application = metadataV1[metadataV1Key(cfg.Group, cfg.DataID)]
+		if metadataV2 == nil {
+			continue
+		}
+		for _, m := range metadataV2.Metadata {
+			if m.Group == cfg.Group && m.DataID == cfg.DataID {
+				if m.Type != "" {
+					cfg.Type = m.Type
+				}
+				cfg.Application = m.AppName
+				cfg.Description = m.Desc
+				break
+			}
+		}
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		if configs[i].Group != configs[j].Group {
+			return configs[i].Group < configs[j].Group
+		}
+		return configs[i].DataID < configs[j].DataID
+	})
+	return configs, nil
+}
+
+func readArchiveFile(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", fmt.Errorf("unable to read %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return "", fmt.Errorf("unable to read %s: %w", f.Name, err)
+	}
+	return string(b), nil
+}
+
+// configurationTypeOf returns the configuration type matching the extension
+// of the data id, `text` when the extension is not a configuration type.
+func configurationTypeOf(dataID string) string {
+	switch ext := strings.TrimPrefix(path.Ext(dataID), "."); ext {
+	case "json", "xml", "yaml", "html", "properties":
+		return ext
+	case "yml":
+		return "yaml"
+	}
+	return "text"
+}
@@ -0,0 +1,139 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/joelee2012/go-nacos"
+)
+
+func readTestArchive(t *testing.T, archive []byte) map[string]string {
+	t.Helper()
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = string(b)
+	}
+	return files
+}
+
+func TestBuildConfigurationArchive(t *testing.T) {
+	configs := []*nacos.Configuration{
+		{DataID: "b.yaml", Group: "DEFAULT_GROUP", Content: "b: 1", Type: "yaml", Application: "app"},
+		{DataID: "a.properties", Group: "DEFAULT_GROUP", Content: "a=1", Type: "properties", Description: `say "hi"`},
+	}
+	archive, err := BuildConfigurationArchive(configs, ExportMetadataV2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := BuildConfigurationArchive([]*nacos.Configuration{configs[1], configs[0]}, ExportMetadataV2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(archive, again) {
+		t.Error("expected the archive to be independent of the order of the configurations")
+	}
+
+	files := readTestArchive(t, archive)
+	for _, cfg := range configs {
+		if got := files[cfg.GetGroup()+"/"+cfg.DataID]; got != cfg.Content {
+			t.Errorf("expected content %q for %s, got %q", cfg.Content, cfg.DataID, got)
+		}
+	}
+	metadata := `metadata:
+- dataId: "a.properties"
+  group: "DEFAULT_GROUP"
+  type: "properties"
+  appName: ""
+  desc: "say \"hi\""
+- dataId: "b.yaml"
+  group: "DEFAULT_GROUP"
+  type: "yaml"
+  appName: "app"
+  desc: ""
+`
+	if got := files[".metadata.yml"]; got != metadata {
+		t.Errorf("expected metadata %q, got %q", metadata, got)
+	}
+
+	archive, err = BuildConfigurationArchive(configs, ExportMetadataV1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files = readTestArchive(t, archive)
+	if got, want := files[".meta.yml"], "DEFAULT_GROUP.b~yaml.app=app\r\n"; got != want {
+		t.Errorf("expected metadata %q, got %q", want, got)
+	}
+
+	if _, err := BuildConfigurationArchive(configs, "v3"); err == nil {
+		t.Error("expected error for unsupported metadata format")
+	}
+}
+
+func TestParseConfigurationArchive(t *testing.T) {
+	configs := []*nacos.Configuration{
+		{DataID: "b.yml", Group: "DEFAULT_GROUP", Content: "b: 1", Type: "text", Application: "app", Description: "b"},
+		{DataID: "a.properties", Group: "test-group", Content: "a=1", Type: "properties"},
+	}
+	archive, err := BuildConfigurationArchive(configs, ExportMetadataV2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseConfigurationArchive(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 configurations, got %d", len(got))
+	}
+	for i, want := range []*nacos.Configuration{configs[0], configs[1]} {
+		if *got[i] != *want {
+			t.Errorf("expected configuration %+v, got %+v", *want, *got[i])
+		}
+	}
+
+	// Without .metadata.yml the type is derived from the data id.
+	archive, err = BuildConfigurationArchive(configs, ExportMetadataV1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = ParseConfigurationArchive(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].Type != "yaml" || got[0].Application != "app" || got[0].Description != "" {
+		t.Errorf("unexpected configuration %+v", *got[0])
+	}
+
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	if _, err := w.Create("no-group"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseConfigurationArchive(buf.Bytes()); err == nil {
+		t.Error("expected error for file without group")
+	}
+	if _, err := ParseConfigurationArchive([]byte("not a zip")); err == nil {
+		t.Error("expected error for invalid archive")
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// cipherDataIDPrefix is the data id prefix of configurations handled by the
+// Nacos config encryption plugin, the full format is `cipher-<algorithm>-<data_id>`.
+const cipherDataIDPrefix = "cipher-"
+
+const dataKeyAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// IsCipherDataID reports whether the configuration content of the data id is
+// encrypted by the Nacos config encryption plugin.
+func IsCipherDataID(dataID string) bool {
+	return strings.HasPrefix(dataID, cipherDataIDPrefix)
+}
+
+// cipherAlgorithm returns the algorithm name of a cipher data id.
+func cipherAlgorithm(dataID string) string {
+	algorithm, _, _ := strings.Cut(strings.TrimPrefix(dataID, cipherDataIDPrefix), "-")
+	return algorithm
+}
+
+// DataKeyManager protects the per configuration data keys, which are stored
+// encrypted next to the configuration as `encryptedDataKey`. Implement it to
+// back the data keys with a key management service.
+type DataKeyManager interface {
+	// Algorithm returns the algorithm name used in cipher data ids.
+	Algorithm() string
+	EncryptDataKey(ctx context.Context, dataKey string) (string, error)
+	DecryptDataKey(ctx context.Context, encryptedDataKey string) (string, error)
+}
+
+// AESDataKeyManager is a DataKeyManager encrypting data keys with a local AES
+// key, compatible with the AES plugin of nacos-group/nacos-plugin.
+type AESDataKeyManager struct {
+	key []byte
+}
+
+var _ DataKeyManager = &AESDataKeyManager{}
+
+// NewAESDataKeyManager returns an AESDataKeyManager for the key, which must be
+// 16, 24 or 32 bytes long.
+func NewAESDataKeyManager(key string) (*AESDataKeyManager, error) {
+	if _, err := aes.NewCipher([]byte(key)); err != nil {
+		return nil, fmt.Errorf("invalid AES key: %w", err)
+	}
+	return &AESDataKeyManager{key: []byte(key)}, nil
+}
+
+func (m *AESDataKeyManager) Algorithm() string {
+	return "aes"
+}
+
+func (m *AESDataKeyManager) EncryptDataKey(_ context.Context, dataKey string) (string, error) {
+	return aesEncrypt(m.key, dataKey)
+}
+
+func (m *AESDataKeyManager) DecryptDataKey(_ context.Context, encryptedDataKey string) (string, error) {
+	return aesDecrypt(m.key, encryptedDataKey)
+}
+
+// ConfigurationCipher encrypts and decrypts the content of cipher data ids in
+// the same way as the Nacos client with the config encryption plugin: the
+// content is encrypted with a random data key, and the data key is encrypted
+// by the DataKeyManager.
+type ConfigurationCipher struct {
+	keys DataKeyManager
+}
+
+func NewConfigurationCipher(keys DataKeyManager) *ConfigurationCipher {
+	return &ConfigurationCipher{keys: keys}
+}
+
+// Supports reports whether the cipher can handle the data id.
+func (c *ConfigurationCipher) Supports(dataID string) error {
+	if c == nil {
+		return errors.New("the provider encryption_key must be set to manage configurations with a cipher- data id")
+	}
+	if algorithm := cipherAlgorithm(dataID); algorithm != c.keys.Algorithm() {
+		return fmt.Errorf("unsupported encryption algorithm %q in data id %q, expected %q", algorithm, dataID, c.keys.Algorithm())
+	}
+	return nil
+}
+
+// Encrypt returns the encrypted content and encrypted data key.
+func (c *ConfigurationCipher) Encrypt(ctx context.Context, content string) (string, string, error) {
+	dataKey, err := generateDataKey()
+	if err != nil {
+		return "", "", err
+	}
+	encrypted, err := aesEncrypt([]byte(dataKey), content)
+	if err != nil {
+		return "", "", err
+	}
+	encryptedDataKey, err := c.keys.EncryptDataKey(ctx, dataKey)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to encrypt data key: %w", err)
+	}
+	return encrypted, encryptedDataKey, nil
+}
+
+// Decrypt returns the plain content.
+func (c *ConfigurationCipher) Decrypt(ctx context.Context, content, encryptedDataKey string) (string, error) {
+	if encryptedDataKey == "" {
+		return "", errors.New("configuration has no encrypted data key")
+	}
+	dataKey, err := c.keys.DecryptDataKey(ctx, encryptedDataKey)
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt data key: %w", err)
+	}
+	return aesDecrypt([]byte(dataKey), content)
+}
+
+// EncryptConfig encrypts the content of the options in place when the data
+// id is a cipher data id.
+func (c *ConfigurationCipher) EncryptConfig(ctx context.Context, opts *PublishCfgOpts) error {
+	if !IsCipherDataID(opts.DataID) {
+		return nil
+	}
+	if err := c.Supports(opts.DataID); err != nil {
+		return err
+	}
+	content, encryptedDataKey, err := c.Encrypt(ctx, opts.Content)
+	if err != nil {
+		return err
+	}
+	opts.Content = content
+	opts.EncryptedDataKey = encryptedDataKey
+	return nil
+}
+
+// DecryptContent returns the plain content of a configuration, the content
+// is returned unchanged when the data id is not a cipher data id.
+func (c *ConfigurationCipher) DecryptContent(ctx context.Context, dataID, content, encryptedDataKey string) (string, error) {
+	if !IsCipherDataID(dataID) {
+		return content, nil
+	}
+	if err := c.Supports(dataID); err != nil {
+		return "", err
+	}
+	return c.Decrypt(ctx, content, encryptedDataKey)
+}
+
+func generateDataKey() (string, error) {
+	key := make([]byte, 16)
+	alphabetSize := big.NewInt(int64(len(dataKeyAlphabet)))
+	for i := range key {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", fmt.Errorf("unable to generate data key: %w", err)
+		}
+		key[i] = dataKeyAlphabet[n.Int64()]
+	}
+	return string(key), nil
+}
+
+// aesEncrypt encrypts with AES/ECB/PKCS5Padding and encodes the result with
+// base64, which is what the Nacos AES encryption plugin uses.
+func aesEncrypt(key []byte, plain string) (string, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	data := pkcs5Pad([]byte(plain), block.BlockSize())
+	ecbCrypt(block, data, block.Encrypt)
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+func aesDecrypt(key []byte, encrypted string) (string, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted content: %w", err)
+	}
+	if len(data) == 0 || len(data)%block.BlockSize() != 0 {
+		return "", errors.New("invalid encrypted content: not a multiple of the block size")
+	}
+	ecbCrypt(block, data, block.Decrypt)
+	plain, err := pkcs5Unpad(data, block.BlockSize())
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// ecbCrypt applies fn to each block of data in place.
+func ecbCrypt(block cipher.Block, data []byte, fn func(dst, src []byte)) {
+	size := block.BlockSize()
+	for i := 0; i < len(data); i += size {
+		fn(data[i:i+size], data[i:i+size])
+	}
+}
+
+func pkcs5Pad(data []byte, size int) []byte {
+	padding := size - len(data)%size
+	return append(data, bytes.Repeat([]byte{byte(padding)}, padding)...)
+}
+
+func pkcs5Unpad(data []byte, size int) ([]byte, error) {
+	padding := int(data[len(data)-1])
+	if padding == 0 || padding > size || padding > len(data) {
+		return nil, errors.New("invalid encrypted content: bad padding")
+	}
+	for _, b := range data[len(data)-padding:] {
+		if int(b) != padding {
+			return nil, errors.New("invalid encrypted content: bad padding")
+		}
+	}
+	return data[:len(data)-padding], nil
+}
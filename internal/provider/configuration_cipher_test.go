@@ -0,0 +1,68 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAESEncrypt(t *testing.T) {
+	// Generated with `openssl enc -aes-128-ecb -base64`.
+	expected := "XWQuibYUHcSi/cuTlvJ1gg=="
+	encrypted, err := aesEncrypt([]byte("0123456789abcdef"), "hello nacos")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if encrypted != expected {
+		t.Errorf("expected %s, got %s", expected, encrypted)
+	}
+	plain, err := aesDecrypt([]byte("0123456789abcdef"), expected)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if plain != "hello nacos" {
+		t.Errorf("expected hello nacos, got %s", plain)
+	}
+}
+
+func TestConfigurationCipher(t *testing.T) {
+	ctx := context.Background()
+	keys, err := NewAESDataKeyManager("0123456789abcdef")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	c := NewConfigurationCipher(keys)
+
+	opts := &PublishCfgOpts{DataID: "cipher-aes-application.yaml", Content: "db:\n  password: secret\n"}
+	if err := c.EncryptConfig(ctx, opts); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if opts.Content == "db:\n  password: secret\n" || opts.EncryptedDataKey == "" {
+		t.Fatalf("expected content to be encrypted, got %q with data key %q", opts.Content, opts.EncryptedDataKey)
+	}
+	content, err := c.DecryptContent(ctx, opts.DataID, opts.Content, opts.EncryptedDataKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if content != "db:\n  password: secret\n" {
+		t.Errorf("unexpected decrypted content %q", content)
+	}
+
+	plain := &PublishCfgOpts{DataID: "application.yaml", Content: "a: b"}
+	if err := c.EncryptConfig(ctx, plain); err != nil || plain.Content != "a: b" {
+		t.Errorf("expected plain data id to be left unchanged, got %q: %v", plain.Content, err)
+	}
+
+	if err := c.EncryptConfig(ctx, &PublishCfgOpts{DataID: "cipher-kms-application.yaml"}); err == nil {
+		t.Error("expected error for unsupported algorithm")
+	}
+	var missing *ConfigurationCipher
+	if err := missing.EncryptConfig(ctx, &PublishCfgOpts{DataID: "cipher-aes-application.yaml"}); err == nil {
+		t.Error("expected error without encryption key")
+	}
+	if _, err := NewAESDataKeyManager("short"); err == nil {
+		t.Error("expected error for invalid key length")
+	}
+}
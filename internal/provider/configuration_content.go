@@ -0,0 +1,438 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"gopkg.in/yaml.v3"
+)
+
+// contentSchemaURL is the resource name the content schema is registered
+// under when compiling it.
+const contentSchemaURL = "content_schema.json"
+
+// privateMd5Key is the private state key holding the md5 of the
+// configuration content last seen on the server.
+const privateMd5Key = "md5"
+
+// privatePendingKey is the private state key holding the data ids of a
+// nacos_configurations resource which failed to publish or delete.
+const privatePendingKey = "pending"
+
+// PrivateState is implemented by the private state of resource requests and
+// responses.
+type PrivateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// ContentSHA256 returns the hex encoded SHA-256 of the content.
+func ContentSHA256(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// SetPrivateMd5 records the server md5 of the configuration content in
+// private state.
+func SetPrivateMd5(ctx context.Context, private PrivateState, md5 string) diag.Diagnostics {
+	value, err := json.Marshal(md5)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Unable to encode configuration md5", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, privateMd5Key, value)
+}
+
+// GetPrivateMd5 returns the server md5 of the configuration content recorded
+// in private state, or an empty string when none was recorded.
+func GetPrivateMd5(ctx context.Context, private PrivateState) (string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, privateMd5Key)
+	if diags.HasError() || len(value) == 0 {
+		return "", diags
+	}
+	var md5 string
+	if err := json.Unmarshal(value, &md5); err != nil {
+		diags.AddError("Unable to decode configuration md5", err.Error())
+	}
+	return md5, diags
+}
+
+// SetPrivatePending records the data ids which still have to be published or
+// deleted in private state, an empty list clears the record.
+func SetPrivatePending(ctx context.Context, private PrivateState, dataIDs []string) diag.Diagnostics {
+	if len(dataIDs) == 0 {
+		return private.SetKey(ctx, privatePendingKey, nil)
+	}
+	value, err := json.Marshal(dataIDs)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Unable to encode pending configurations", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, privatePendingKey, value)
+}
+
+// GetPrivatePending returns the data ids recorded by SetPrivatePending.
+func GetPrivatePending(ctx context.Context, private PrivateState) ([]string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, privatePendingKey)
+	if diags.HasError() || len(value) == 0 {
+		return nil, diags
+	}
+	var dataIDs []string
+	if err := json.Unmarshal(value, &dataIDs); err != nil {
+		diags.AddError("Unable to decode pending configurations", err.Error())
+	}
+	return dataIDs, diags
+}
+
+// ContentSchemaViolation describes a single location in the configuration
+// content which does not satisfy the content schema.
+type ContentSchemaViolation struct {
+	// Pointer is the JSON pointer of the failing value, empty for the document root.
+	Pointer string
+	Message string
+}
+
+// ParseContent decodes configuration content of the given type into a
+// JSON compatible value. Only `json` and `yaml` content can be parsed.
+func ParseContent(contentType, content string) (any, error) {
+	switch contentType {
+	case "json":
+		var doc any
+		decoder := json.NewDecoder(strings.NewReader(content))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("invalid json content: %w", err)
+		}
+		return doc, nil
+	case "yaml":
+		var doc any
+		if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+			return nil, fmt.Errorf("invalid yaml content: %w", err)
+		}
+		// Round trip through encoding/json so that the document only holds
+		// the value types produced by the json decoder.
+		data, err := json.Marshal(normalizeYAML(doc))
+		if err != nil {
+			return nil, fmt.Errorf("invalid yaml content: %w", err)
+		}
+		return jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	default:
+		return nil, fmt.Errorf("unable to parse content of type %q, expected one of json, yaml", contentType)
+	}
+}
+
+// normalizeYAML converts maps with non string keys, which yaml.v3 produces
+// for e.g. integer keys, into maps with string keys.
+func normalizeYAML(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			val[k] = normalizeYAML(item)
+		}
+		return val
+	case map[any]any:
+		m := make(map[string]any, len(val))
+		for k, item := range val {
+			m[fmt.Sprint(k)] = normalizeYAML(item)
+		}
+		return m
+	case []any:
+		for i, item := range val {
+			val[i] = normalizeYAML(item)
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+// CompileContentSchema compiles a JSON Schema document.
+func CompileContentSchema(schema string) (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("invalid json: %w", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(contentSchemaURL, doc); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(contentSchemaURL)
+}
+
+// ValidateContentSchema validates the parsed content against the schema and
+// returns one violation per failing leaf of the validation result.
+func ValidateContentSchema(schema *jsonschema.Schema, doc any) []ContentSchemaViolation {
+	err := schema.Validate(doc)
+	if err == nil {
+		return nil
+	}
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return []ContentSchemaViolation{{Message: err.Error()}}
+	}
+	var violations []ContentSchemaViolation
+	collectViolations(validationErr, &violations)
+	return violations
+}
+
+func collectViolations(err *jsonschema.ValidationError, violations *[]ContentSchemaViolation) {
+	if len(err.Causes) == 0 {
+		out := err.BasicOutput()
+		*violations = append(*violations, ContentSchemaViolation{
+			Pointer: out.InstanceLocation,
+			Message: out.Error.String(),
+		})
+		return
+	}
+	for _, cause := range err.Causes {
+		collectViolations(cause, violations)
+	}
+}
+
+// StructuredValue converts a Terraform value into plain Go values: objects and
+// maps become map[string]any, lists, sets and tuples become []any and numbers
+// become int64 when they are whole numbers or float64 otherwise. The boolean
+// result is false when the value is, or contains, an unknown value.
+func StructuredValue(v attr.Value) (any, bool, error) {
+	if v == nil || v.IsNull() {
+		return nil, true, nil
+	}
+	if v.IsUnknown() {
+		return nil, false, nil
+	}
+	switch val := v.(type) {
+	case basetypes.DynamicValue:
+		return StructuredValue(val.UnderlyingValue())
+	case basetypes.StringValue:
+		return val.ValueString(), true, nil
+	case basetypes.BoolValue:
+		return val.ValueBool(), true, nil
+	case basetypes.Int64Value:
+		return val.ValueInt64(), true, nil
+	case basetypes.Float64Value:
+		return val.ValueFloat64(), true, nil
+	case basetypes.NumberValue:
+		number := val.ValueBigFloat()
+		if number.IsInt() {
+			if i, accuracy := number.Int64(); accuracy == 0 {
+				return i, true, nil
+			}
+		}
+		f, _ := number.Float64()
+		return f, true, nil
+	case basetypes.ObjectValue:
+		return structuredMap(val.Attributes())
+	case basetypes.MapValue:
+		return structuredMap(val.Elements())
+	case basetypes.ListValue:
+		return structuredList(val.Elements())
+	case basetypes.SetValue:
+		return structuredList(val.Elements())
+	case basetypes.TupleValue:
+		return structuredList(val.Elements())
+	default:
+		return nil, false, fmt.Errorf("unsupported value %T", v)
+	}
+}
+
+func structuredMap(elements map[string]attr.Value) (any, bool, error) {
+	m := make(map[string]any, len(elements))
+	for k, element := range elements {
+		v, known, err := StructuredValue(element)
+		if err != nil || !known {
+			return nil, known, err
+		}
+		m[k] = v
+	}
+	return m, true, nil
+}
+
+func structuredList(elements []attr.Value) (any, bool, error) {
+	l := make([]any, 0, len(elements))
+	for _, element := range elements {
+		v, known, err := StructuredValue(element)
+		if err != nil || !known {
+			return nil, known, err
+		}
+		l = append(l, v)
+	}
+	return l, true, nil
+}
+
+// RenderStructuredContent serializes a value returned by StructuredValue into
+// configuration content of the given type. Object keys are always sorted, so
+// the same value renders to the same content.
+func RenderStructuredContent(contentType string, v any) (string, error) {
+	switch contentType {
+	case "json":
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(v); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	case "yaml":
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(v); err != nil {
+			return "", err
+		}
+		if err := encoder.Close(); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	case "properties":
+		return encodeProperties(v)
+	default:
+		return "", fmt.Errorf("unable to render content of type %q, expected one of json, yaml, properties", contentType)
+	}
+}
+
+// encodeProperties flattens nested objects into dotted keys and lists into
+// indexed keys, e.g. `server.hosts[0]`, and writes them sorted by key with
+// list indices in numeric order.
+func encodeProperties(v any) (string, error) {
+	root, ok := v.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("properties content must be an object, got %T", v)
+	}
+	entries := map[string]string{}
+	if err := flattenProperties("", root, entries); err != nil {
+		return "", err
+	}
+
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return propertiesKeyLess(keys[i], keys[j])
+	})
+
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(escapePropertiesKey(k))
+		sb.WriteByte('=')
+		sb.WriteString(escapePropertiesValue(entries[k]))
+		sb.WriteByte('\n')
+	}
+	return sb.String(), nil
+}
+
+// flattenProperties adds the entries of v under prefix. A flattened key such
+// as `a.b` which collides with a nested one such as `{a = {b = ...}}` is an
+// error, instead of one silently overwriting the other.
+func flattenProperties(prefix string, v any, entries map[string]string) error {
+	switch val := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			if err := flattenProperties(key, val[k], entries); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		for i, item := range val {
+			if err := flattenProperties(fmt.Sprintf("%s[%d]", prefix, i), item, entries); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if _, ok := entries[prefix]; ok {
+		return fmt.Errorf("properties key %q is defined more than once", prefix)
+	}
+	switch val := v.(type) {
+	case nil:
+		entries[prefix] = ""
+	case string:
+		entries[prefix] = val
+	case float64:
+		entries[prefix] = strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		entries[prefix] = fmt.Sprint(val)
+	}
+	return nil
+}
+
+// propertiesKeyLess orders properties keys as strings, except that list
+// indices are compared as numbers so `a[2]` sorts before `a[10]`.
+func propertiesKeyLess(a, b string) bool {
+	for a != "" && b != "" {
+		ia, restA, okA := leadingPropertiesIndex(a)
+		ib, restB, okB := leadingPropertiesIndex(b)
+		if okA && okB {
+			if ia != ib {
+				return ia < ib
+			}
+			a, b = restA, restB
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+// leadingPropertiesIndex parses a list index such as `[10]` at the start of
+// s and returns it with the rest of s.
+func leadingPropertiesIndex(s string) (int, string, bool) {
+	if !strings.HasPrefix(s, "[") {
+		return 0, s, false
+	}
+	end := strings.IndexByte(s, ']')
+	if end < 2 {
+		return 0, s, false
+	}
+	index, err := strconv.Atoi(s[1:end])
+	if err != nil || index < 0 {
+		return 0, s, false
+	}
+	return index, s[end+1:], true
+}
+
+var propertiesKeyReplacer = strings.NewReplacer(`\`, `\\`, "=", `\=`, ":", `\:`, " ", `\ `, "#", `\#`, "!", `\!`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+var propertiesValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func escapePropertiesKey(k string) string {
+	return propertiesKeyReplacer.Replace(k)
+}
+
+func escapePropertiesValue(v string) string {
+	v = propertiesValueReplacer.Replace(v)
+	// Leading whitespace is stripped by properties parsers unless escaped.
+	if strings.HasPrefix(v, " ") {
+		v = `\` + v
+	}
+	return v
+}
@@ -0,0 +1,276 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testGatewaySchema = `{
+  "type": "object",
+  "required": ["routes"],
+  "properties": {
+    "routes": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["uri"],
+        "properties": {
+          "uri": {"type": "string"}
+        }
+      }
+    }
+  }
+}`
+
+func TestValidateContentSchema(t *testing.T) {
+	schema, err := CompileContentSchema(testGatewaySchema)
+	if err != nil {
+		t.Fatalf("unexpected error compiling schema: %s", err)
+	}
+
+	cases := []struct {
+		name        string
+		contentType string
+		content     string
+		pointers    []string
+	}{
+		{
+			name:        "valid yaml",
+			contentType: "yaml",
+			content:     "routes:\n  - uri: lb://orders\n  - uri: lb://payments\n",
+		},
+		{
+			name:        "valid json",
+			contentType: "json",
+			content:     `{"routes": [{"uri": "lb://orders"}]}`,
+		},
+		{
+			name:        "missing uri",
+			contentType: "yaml",
+			content:     "routes:\n  - uri: lb://orders\n  - id: payments\n",
+			pointers:    []string{"/routes/1"},
+		},
+		{
+			name:        "wrong type",
+			contentType: "json",
+			content:     `{"routes": [{"uri": 8080}]}`,
+			pointers:    []string{"/routes/0/uri"},
+		},
+		{
+			name:        "missing routes",
+			contentType: "yaml",
+			content:     "server:\n  port: 80\n",
+			pointers:    []string{""},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := ParseContent(tc.contentType, tc.content)
+			if err != nil {
+				t.Fatalf("unexpected error parsing content: %s", err)
+			}
+			violations := ValidateContentSchema(schema, doc)
+			if len(violations) != len(tc.pointers) {
+				t.Fatalf("expected %d violations, got %d: %v", len(tc.pointers), len(violations), violations)
+			}
+			for i, violation := range violations {
+				if violation.Pointer != tc.pointers[i] {
+					t.Errorf("expected violation at %q, got %q", tc.pointers[i], violation.Pointer)
+				}
+			}
+		})
+	}
+}
+
+func TestParseContent(t *testing.T) {
+	if _, err := ParseContent("yaml", "a: [1, 2"); err == nil {
+		t.Error("expected error for invalid yaml")
+	}
+	if _, err := ParseContent("json", "{"); err == nil {
+		t.Error("expected error for invalid json")
+	}
+	if _, err := ParseContent("text", "a"); err == nil {
+		t.Error("expected error for text content")
+	}
+	if _, err := ParseContent("yaml", "1: one\n2: two\n"); err != nil {
+		t.Errorf("unexpected error for integer keys: %s", err)
+	}
+}
+
+func TestRenderStructuredContent(t *testing.T) {
+	value := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{
+			"server": types.ObjectType{AttrTypes: map[string]attr.Type{
+				"port":  types.NumberType,
+				"hosts": types.TupleType{ElemTypes: []attr.Type{types.StringType, types.StringType}},
+			}},
+			"enabled": types.BoolType,
+			"ratio":   types.NumberType,
+			"motd":    types.StringType,
+		},
+		map[string]attr.Value{
+			"server": types.ObjectValueMust(
+				map[string]attr.Type{
+					"port":  types.NumberType,
+					"hosts": types.TupleType{ElemTypes: []attr.Type{types.StringType, types.StringType}},
+				},
+				map[string]attr.Value{
+					"port": types.NumberValue(big.NewFloat(8080)),
+					"hosts": types.TupleValueMust(
+						[]attr.Type{types.StringType, types.StringType},
+						[]attr.Value{types.StringValue("a.example.com"), types.StringValue("b.example.com")},
+					),
+				},
+			),
+			"enabled": types.BoolValue(true),
+			"ratio":   types.NumberValue(big.NewFloat(0.5)),
+			"motd":    types.StringValue(" hello: world"),
+		},
+	))
+
+	v, known, err := StructuredValue(value)
+	if err != nil || !known {
+		t.Fatalf("unexpected result converting value: known=%t err=%v", known, err)
+	}
+
+	cases := map[string]string{
+		"json": `{
+  "enabled": true,
+  "motd": " hello: world",
+  "ratio": 0.5,
+  "server": {
+    "hosts": [
+      "a.example.com",
+      "b.example.com"
+    ],
+    "port": 8080
+  }
+}
+`,
+		"yaml": `enabled: true
+motd: ' hello: world'
+ratio: 0.5
+server:
+  hosts:
+    - a.example.com
+    - b.example.com
+  port: 8080
+`,
+		"properties": `enabled=true
+motd=\ hello: world
+ratio=0.5
+server.hosts[0]=a.example.com
+server.hosts[1]=b.example.com
+server.port=8080
+`,
+	}
+	for contentType, expected := range cases {
+		content, err := RenderStructuredContent(contentType, v)
+		if err != nil {
+			t.Fatalf("unexpected error rendering %s: %s", contentType, err)
+		}
+		if content != expected {
+			t.Errorf("unexpected %s content:\n%s\nexpected:\n%s", contentType, content, expected)
+		}
+	}
+
+	if _, err := RenderStructuredContent("text", v); err == nil {
+		t.Error("expected error rendering text content")
+	}
+	if _, err := RenderStructuredContent("properties", []any{"a"}); err == nil {
+		t.Error("expected error rendering a list as properties")
+	}
+	if _, known, _ := StructuredValue(types.DynamicUnknown()); known {
+		t.Error("expected unknown value to be reported as unknown")
+	}
+}
+
+func TestRenderStructuredContentOrderAndEscaping(t *testing.T) {
+	hosts := make([]any, 12)
+	for i := range hosts {
+		hosts[i] = fmt.Sprintf("h%d", i)
+	}
+	content, err := RenderStructuredContent("properties", map[string]any{"hosts": hosts})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	for i, line := range lines {
+		if expected := fmt.Sprintf("hosts[%d]=h%d", i, i); line != expected {
+			t.Errorf("unexpected line %d %q, expected %q", i, line, expected)
+		}
+	}
+
+	collision := map[string]any{
+		"a.b": "flat",
+		"a":   map[string]any{"b": "nested"},
+	}
+	if _, err := RenderStructuredContent("properties", collision); err == nil {
+		t.Error("expected error rendering colliding properties keys")
+	}
+
+	content, err = RenderStructuredContent("json", map[string]any{"url": "http://a/?x=1&y=<2>"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "{\n  \"url\": \"http://a/?x=1&y=<2>\"\n}\n"; content != expected {
+		t.Errorf("unexpected json content %q, expected %q", content, expected)
+	}
+}
+
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestPrivateMd5(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateState{}
+	if md5, diags := GetPrivateMd5(ctx, private); diags.HasError() || md5 != "" {
+		t.Fatalf("expected empty md5, got %q: %v", md5, diags)
+	}
+	if diags := SetPrivateMd5(ctx, private, "d41d8cd98f00b204e9800998ecf8427e"); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if md5, diags := GetPrivateMd5(ctx, private); diags.HasError() || md5 != "d41d8cd98f00b204e9800998ecf8427e" {
+		t.Errorf("unexpected md5 %q: %v", md5, diags)
+	}
+}
+
+func TestPrivatePending(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateState{}
+	if pending, diags := GetPrivatePending(ctx, private); diags.HasError() || len(pending) != 0 {
+		t.Fatalf("expected no pending configurations, got %v: %v", pending, diags)
+	}
+	if diags := SetPrivatePending(ctx, private, []string{"a.yaml", "b.yaml"}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if pending, diags := GetPrivatePending(ctx, private); diags.HasError() || !reflect.DeepEqual(pending, []string{"a.yaml", "b.yaml"}) {
+		t.Errorf("unexpected pending configurations %v: %v", pending, diags)
+	}
+	if diags := SetPrivatePending(ctx, private, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if pending, diags := GetPrivatePending(ctx, private); diags.HasError() || len(pending) != 0 {
+		t.Errorf("expected pending configurations to be cleared, got %v: %v", pending, diags)
+	}
+}
@@ -0,0 +1,680 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GetContentKeys returns the values of the keys present in the content.
+// Keys of `properties` content are property keys and values are returned as
+// they are, keys of `json` and `yaml` content are dotted paths of object
+// keys and values are returned JSON encoded.
+func GetContentKeys(contentType, content string, keys []string) (map[string]string, error) {
+	values := make(map[string]string)
+	switch contentType {
+	case "properties":
+		for _, line := range parsePropertiesLines(content) {
+			if line.key != "" {
+				values[line.key] = line.value
+			}
+		}
+		for key := range values {
+			if !containsString(keys, key) {
+				delete(values, key)
+			}
+		}
+		return values, nil
+	case "json", "yaml":
+		doc, err := parseContentNode(content)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			node := lookupNode(doc, strings.Split(key, "."))
+			if node == nil {
+				continue
+			}
+			var v any
+			if err := node.Decode(&v); err != nil {
+				return nil, fmt.Errorf("unable to decode %s: %w", key, err)
+			}
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("unable to encode %s: %w", key, err)
+			}
+			values[key] = string(b)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unable to edit content of type %q, expected one of json, yaml, properties", contentType)
+	}
+}
+
+// NormalizeContentKeys replaces the values returned by GetContentKeys of
+// `json` and `yaml` content with the configured values when both decode to
+// the same JSON value, e.g. `1.0` and `1`, so that values which are not
+// written as json.Marshal writes them do not show a difference.
+func NormalizeContentKeys(contentType string, values, configured map[string]string) {
+	if contentType != "json" && contentType != "yaml" {
+		return
+	}
+	for key, value := range values {
+		want, ok := configured[key]
+		if ok && want != value && sameJSONValue(want, value) {
+			values[key] = want
+		}
+	}
+}
+
+func sameJSONValue(a, b string) bool {
+	var va, vb any
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// EditContent sets and removes keys of the content, leaving the other keys
+// and comments untouched. Keys and values are as for GetContentKeys, new
+// keys are appended in sorted order. When only values of existing scalar
+// keys of `json` and `yaml` content are set, the new values are spliced into
+// the content, otherwise the document is encoded again with its indent.
+func EditContent(contentType, content string, set map[string]string, remove []string) (string, error) {
+	switch contentType {
+	case "properties":
+		return editProperties(content, set, remove), nil
+	case "json", "yaml":
+		doc, err := parseContentNode(content)
+		if err != nil {
+			return "", err
+		}
+		indent := yamlIndent(doc.Content[0])
+		var splices []contentSplice
+		spliceable := true
+		for _, key := range sortedKeys(set) {
+			var v any
+			if err := json.Unmarshal([]byte(set[key]), &v); err != nil {
+				return "", fmt.Errorf("value of %s must be JSON encoded: %w", key, err)
+			}
+			value := new(yaml.Node)
+			if err := value.Encode(v); err != nil {
+				return "", fmt.Errorf("unable to encode %s: %w", key, err)
+			}
+			path := strings.Split(key, ".")
+			old := lookupNode(doc, path)
+			if err := setNode(doc, path, value); err != nil {
+				return "", fmt.Errorf("unable to set %s: %w", key, err)
+			}
+			if spliceable {
+				var splice contentSplice
+				splice, spliceable = newContentSplice(contentType, content, old, value, set[key])
+				splices = append(splices, splice)
+			}
+		}
+		for _, key := range remove {
+			path := strings.Split(key, ".")
+			if lookupNode(doc, path) != nil {
+				spliceable = false
+			}
+			removeNode(doc, path)
+		}
+		if spliceable {
+			if edited, ok := spliceContent(content, splices, doc); ok {
+				return edited, nil
+			}
+		}
+		if contentType == "json" {
+			return renderJSONNode(doc, jsonIndent(content))
+		}
+		buf := new(bytes.Buffer)
+		enc := yaml.NewEncoder(buf)
+		enc.SetIndent(indent)
+		if err := enc.Encode(doc); err != nil {
+			return "", err
+		}
+		if err := enc.Close(); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	default:
+		return "", fmt.Errorf("unable to edit content of type %q, expected one of json, yaml, properties", contentType)
+	}
+}
+
+// contentSplice replaces the text between start and end of the content.
+type contentSplice struct {
+	start, end int
+	text       string
+}
+
+// newContentSplice returns the splice replacing the old scalar node with the
+// new value, it reports false when the value cannot be spliced.
+func newContentSplice(contentType, content string, old, value *yaml.Node, encoded string) (contentSplice, bool) {
+	if old == nil || old.Kind != yaml.ScalarNode {
+		return contentSplice{}, false
+	}
+	start := nodeOffset(content, old.Line, old.Column)
+	if start < 0 {
+		return contentSplice{}, false
+	}
+	end := scalarEnd(content, start, old.Style, contentType == "json")
+	if end < 0 {
+		return contentSplice{}, false
+	}
+	if contentType == "json" {
+		buf := new(bytes.Buffer)
+		if err := json.Compact(buf, []byte(encoded)); err != nil {
+			return contentSplice{}, false
+		}
+		return contentSplice{start: start, end: end, text: buf.String()}, true
+	}
+	if value.Kind != yaml.ScalarNode {
+		return contentSplice{}, false
+	}
+	// The comments of the value are kept in the content.
+	scalar := *value
+	scalar.HeadComment, scalar.LineComment, scalar.FootComment = "", "", ""
+	b, err := yaml.Marshal(&scalar)
+	if err != nil {
+		return contentSplice{}, false
+	}
+	text := strings.TrimSuffix(string(b), "\n")
+	if strings.Contains(text, "\n") {
+		return contentSplice{}, false
+	}
+	return contentSplice{start: start, end: end, text: text}, true
+}
+
+// spliceContent applies the splices to the content, it reports false when the
+// result does not decode to the same value as the edited document.
+func spliceContent(content string, splices []contentSplice, doc *yaml.Node) (string, bool) {
+	sort.Slice(splices, func(i, j int) bool { return splices[i].start > splices[j].start })
+	for i, splice := range splices {
+		if i > 0 && splice.end > splices[i-1].start {
+			return "", false
+		}
+		content = content[:splice.start] + splice.text + content[splice.end:]
+	}
+	spliced, err := parseContentNode(content)
+	if err != nil {
+		return "", false
+	}
+	var want, got any
+	if doc.Content[0].Decode(&want) != nil || spliced.Content[0].Decode(&got) != nil {
+		return "", false
+	}
+	return content, reflect.DeepEqual(want, got)
+}
+
+// nodeOffset returns the byte offset of the 1-based line and column of a
+// node, or -1.
+func nodeOffset(content string, line, column int) int {
+	if line < 1 || column < 1 {
+		return -1
+	}
+	offset := 0
+	for l := 1; l < line; l++ {
+		i := strings.IndexByte(content[offset:], '\n')
+		if i < 0 {
+			return -1
+		}
+		offset += i + 1
+	}
+	for c := 1; c < column; c++ {
+		if offset >= len(content) {
+			return -1
+		}
+		_, size := utf8.DecodeRuneInString(content[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// scalarEnd returns the byte offset after the scalar starting at start, or
+// -1 for block scalars. Plain scalars end at a comment or the end of the
+// line, and also at a flow indicator in flow content.
+func scalarEnd(content string, start int, style yaml.Style, flow bool) int {
+	switch {
+	case style&yaml.DoubleQuotedStyle != 0:
+		for i := start + 1; i < len(content); i++ {
+			switch content[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1
+			}
+		}
+	case style&yaml.SingleQuotedStyle != 0:
+		for i := start + 1; i < len(content); i++ {
+			if content[i] != '\'' {
+				continue
+			}
+			if i+1 < len(content) && content[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	case style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		return -1
+	default:
+		end := start
+		for i := start; i < len(content) && content[i] != '\n' && content[i] != '\r'; i++ {
+			if content[i] == '#' && i > start && (content[i-1] == ' ' || content[i-1] == '\t') {
+				break
+			}
+			if flow && strings.IndexByte(",]}", content[i]) >= 0 {
+				break
+			}
+			if content[i] != ' ' && content[i] != '\t' {
+				end = i + 1
+			}
+		}
+		return end
+	}
+	return -1
+}
+
+// yamlIndent returns the indent of the first nested block mapping of the
+// node, 2 when there is none.
+func yamlIndent(node *yaml.Node) int {
+	var find func(node *yaml.Node) int
+	find = func(node *yaml.Node) int {
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if value.Kind == yaml.MappingNode && value.Style&yaml.FlowStyle == 0 && len(value.Content) > 0 && value.Content[0].Column > key.Column {
+					return value.Content[0].Column - key.Column
+				}
+				if indent := find(value); indent > 0 {
+					return indent
+				}
+			}
+		case yaml.SequenceNode:
+			for _, item := range node.Content {
+				if indent := find(item); indent > 0 {
+					return indent
+				}
+			}
+		}
+		return 0
+	}
+	// The yaml encoder supports an indent between 2 and 9.
+	if indent := find(node); indent >= 2 && indent <= 9 {
+		return indent
+	}
+	return 2
+}
+
+// jsonIndent returns the indent of the first indented line of the content,
+// two spaces when there is none.
+func jsonIndent(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if trimmed := strings.TrimLeft(line, " \t"); trimmed != "" && len(trimmed) < len(line) {
+			return line[:len(line)-len(trimmed)]
+		}
+	}
+	return "  "
+}
+
+func containsString(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// propertiesLine is a logical line of properties content, which spans
+// several physical lines when they end with a backslash.
+type propertiesLine struct {
+	// text holds the physical lines including their line breaks.
+	text string
+	// key is empty for blank and comment lines.
+	key   string
+	value string
+}
+
+// parsePropertiesLines splits properties content into logical lines.
+func parsePropertiesLines(content string) []*propertiesLine {
+	var lines []*propertiesLine
+	physical := strings.SplitAfter(content, "\n")
+	for i := 0; i < len(physical); i++ {
+		if physical[i] == "" {
+			continue
+		}
+		line := &propertiesLine{text: physical[i]}
+		trimmed := strings.TrimLeft(strings.TrimRight(physical[i], "\r\n"), " \t\f")
+		if trimmed == "" || trimmed[0] == '#' || trimmed[0] == '!' {
+			lines = append(lines, line)
+			continue
+		}
+		logical := trimmed
+		for endsWithContinuation(logical) && i+1 < len(physical) {
+			i++
+			line.text += physical[i]
+			logical = logical[:len(logical)-1] + strings.TrimLeft(strings.TrimRight(physical[i], "\r\n"), " \t\f")
+		}
+		line.key, line.value = splitProperty(logical)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// endsWithContinuation reports whether the line ends with an odd number of
+// backslashes.
+func endsWithContinuation(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitProperty returns the unescaped key and value of a logical line.
+func splitProperty(line string) (string, string) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if line[i] == '=' || line[i] == ':' || line[i] == ' ' || line[i] == '\t' || line[i] == '\f' {
+			end = i
+			break
+		}
+	}
+	rest := strings.TrimLeft(line[end:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	return unescapeProperties(line[:end]), unescapeProperties(rest)
+}
+
+func unescapeProperties(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if i+4 < len(s) {
+				if r, err := strconv.ParseUint(s[i+1:i+5], 16, 32); err == nil {
+					sb.WriteRune(rune(r))
+					i += 4
+					continue
+				}
+			}
+			sb.WriteByte('u')
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
+
+func editProperties(content string, set map[string]string, remove []string) string {
+	var sb strings.Builder
+	written := make(map[string]bool)
+	for _, line := range parsePropertiesLines(content) {
+		if line.key == "" {
+			sb.WriteString(line.text)
+			continue
+		}
+		if containsString(remove, line.key) {
+			continue
+		}
+		value, ok := set[line.key]
+		if !ok {
+			sb.WriteString(line.text)
+			continue
+		}
+		if written[line.key] {
+			// Drop duplicates of a key which has been set.
+			continue
+		}
+		written[line.key] = true
+		sb.WriteString(escapePropertiesKey(line.key) + "=" + escapePropertiesValue(value) + "\n")
+	}
+	for _, key := range sortedKeys(set) {
+		if written[key] {
+			continue
+		}
+		if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "\n") {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(escapePropertiesKey(key) + "=" + escapePropertiesValue(set[key]) + "\n")
+	}
+	return sb.String()
+}
+
+// parseContentNode parses json or yaml content into a document node, empty
+// content is an empty object.
+func parseContentNode(content string) (*yaml.Node, error) {
+	doc := new(yaml.Node)
+	if err := yaml.Unmarshal([]byte(content), doc); err != nil {
+		return nil, fmt.Errorf("unable to parse content: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc.Kind = yaml.DocumentNode
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	return doc, nil
+}
+
+// parsePropertiesNode parses properties content into a document node holding
+// a mapping of strings, the last value of a duplicated key wins.
+func parsePropertiesNode(content string) *yaml.Node {
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	values := make(map[string]*yaml.Node)
+	for _, line := range parsePropertiesLines(content) {
+		if line.key == "" {
+			continue
+		}
+		if value, ok := values[line.key]; ok {
+			value.Value = line.value
+			continue
+		}
+		values[line.key] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: line.value}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: line.key}, values[line.key])
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}
+}
+
+// lookupNode returns the node at the path of object keys, or nil.
+func lookupNode(doc *yaml.Node, path []string) *yaml.Node {
+	keyPath := make([]KeyPathElement, 0, len(path))
+	for _, key := range path {
+		keyPath = append(keyPath, KeyPathElement{Key: key})
+	}
+	return lookupKeyPath(doc.Content[0], keyPath)
+}
+
+// KeyPathElement is an element of a key path, either an object key or an
+// array index.
+type KeyPathElement struct {
+	Key   string
+	Index int
+	// IsIndex tells whether the element is an array index.
+	IsIndex bool
+}
+
+// lookupKeyPath returns the node at the key path, or nil.
+func lookupKeyPath(node *yaml.Node, keyPath []KeyPathElement) *yaml.Node {
+	for _, element := range keyPath {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		var next *yaml.Node
+		switch {
+		case element.IsIndex && node.Kind == yaml.SequenceNode:
+			if element.Index < len(node.Content) {
+				next = node.Content[element.Index]
+			}
+		case !element.IsIndex && node.Kind == yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == element.Key {
+					next = node.Content[i+1]
+				}
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
+
+// setNode sets the node at the path of object keys, creating missing objects.
+func setNode(doc *yaml.Node, path []string, value *yaml.Node) error {
+	node := doc.Content[0]
+	for depth, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return fmt.Errorf("%s is not an object", strings.Join(path[:depth], "."))
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				if depth == len(path)-1 {
+					// Keep the comments and the quoting of the replaced value.
+					replaced := node.Content[i+1]
+					value.HeadComment = replaced.HeadComment
+					value.LineComment = replaced.LineComment
+					value.FootComment = replaced.FootComment
+					if value.Tag == "!!str" && replaced.Tag == "!!str" {
+						value.Style = replaced.Style & (yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle)
+					}
+					node.Content[i+1] = value
+					return nil
+				}
+				next = node.Content[i+1]
+			}
+		}
+		if next == nil {
+			next = value
+			if depth < len(path)-1 {
+				next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, next)
+		}
+		node = next
+	}
+	return nil
+}
+
+// removeNode removes the node at the path of object keys if it exists.
+func removeNode(doc *yaml.Node, path []string) {
+	parent := lookupNode(doc, path[:len(path)-1])
+	if parent == nil || parent.Kind != yaml.MappingNode {
+		return
+	}
+	key := path[len(path)-1]
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// renderJSONNode renders a document node as JSON indented with indent,
+// keeping the order of object keys.
+func renderJSONNode(doc *yaml.Node, indent string) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := writeJSONNode(buf, doc.Content[0]); err != nil {
+		return "", err
+	}
+	out := new(bytes.Buffer)
+	if err := json.Indent(out, buf.Bytes(), "", indent); err != nil {
+		return "", err
+	}
+	out.WriteByte('\n')
+	return out.String(), nil
+}
+
+func writeJSONNode(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return writeJSONNode(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(node.Content[i].Value)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSONNode(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONNode(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		// Keep numbers as written, e.g. `1.0`.
+		if (node.Tag == "!!int" || node.Tag == "!!float") && json.Valid([]byte(node.Value)) {
+			buf.WriteString(node.Value)
+			return nil
+		}
+		var v any
+		if err := node.Decode(&v); err != nil {
+			return err
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditContent(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		content     string
+		set         map[string]string
+		remove      []string
+		want        string
+	}{
+		{
+			name:        "properties",
+			contentType: "properties",
+			content:     "# shared by all teams\nserver.port=8080\nfeature.a = on\nlong.value=a,\\\n  b\nfeature.b:off\n",
+			set:         map[string]string{"feature.a": "off", "long.value": "c", "feature.c": "new value"},
+			remove:      []string{"feature.b"},
+			want:        "# shared by all teams\nserver.port=8080\nfeature.a=off\nlong.value=c\nfeature.c=new value\n",
+		},
+		{
+			name:        "properties without trailing newline",
+			contentType: "properties",
+			content:     "a=1",
+			set:         map[string]string{"b": "2"},
+			want:        "a=1\nb=2\n",
+		},
+		{
+			name:        "yaml",
+			contentType: "yaml",
+			content:     "# shared\nserver:\n  port: 8080 # the port\n  host: localhost\nfeature:\n  a: true\n",
+			set:         map[string]string{"server.port": "9090", "feature.b": `"beta"`},
+			remove:      []string{"feature.a"},
+			want:        "# shared\nserver:\n  port: 9090 # the port\n  host: localhost\nfeature:\n  b: beta\n",
+		},
+		{
+			name:        "json",
+			contentType: "json",
+			content:     `{"z": 1.0, "server": {"port": 8080}, "a": [1, 2]}`,
+			set:         map[string]string{"server.port": "9090", "server.tls.enabled": "true"},
+			remove:      []string{"a"},
+			want:        "{\n  \"z\": 1.0,\n  \"server\": {\n    \"port\": 9090,\n    \"tls\": {\n      \"enabled\": true\n    }\n  }\n}\n",
+		},
+		{
+			name:        "yaml one key",
+			contentType: "yaml",
+			content:     "# shared by all teams\n\nserver:\n    # the port\n    port: 8080   # changed by ops\n    host: \"localhost\"\n\n    tags: [a, b]\n# end of server\nfeature:\n    a: true\n",
+			set:         map[string]string{"server.port": "9090", "server.host": `"example.com"`},
+			want:        "# shared by all teams\n\nserver:\n    # the port\n    port: 9090   # changed by ops\n    host: \"example.com\"\n\n    tags: [a, b]\n# end of server\nfeature:\n    a: true\n",
+		},
+		{
+			name:        "yaml indent",
+			contentType: "yaml",
+			content:     "# shared\nserver:\n    # the port\n    port: 8080 # changed by ops\nfeature:\n    a: true\n",
+			set:         map[string]string{"server.port": "9090"},
+			remove:      []string{"feature.a"},
+			want:        "# shared\nserver:\n    # the port\n    port: 9090 # changed by ops\nfeature: {}\n",
+		},
+		{
+			name:        "json one key",
+			contentType: "json",
+			content:     "{\n    \"server\": {\"port\": 8080, \"host\": \"localhost\"},\n    \"ratio\": 1.0\n}\n",
+			set:         map[string]string{"server.port": "9090", "server.host": `"example.com"`},
+			want:        "{\n    \"server\": {\"port\": 9090, \"host\": \"example.com\"},\n    \"ratio\": 1.0\n}\n",
+		},
+		{
+			name:        "json indent",
+			contentType: "json",
+			content:     "{\n    \"a\": 1,\n    \"b\": 2\n}\n",
+			remove:      []string{"b"},
+			want:        "{\n    \"a\": 1\n}\n",
+		},
+		{
+			name:        "empty json",
+			contentType: "json",
+			content:     "",
+			set:         map[string]string{"a": `"b"`},
+			want:        "{\n  \"a\": \"b\"\n}\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := EditContent(c.contentType, c.content, c.set, c.remove)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != c.want {
+				t.Errorf("expected %q, got %q", c.want, got)
+			}
+		})
+	}
+
+	if _, err := EditContent("json", `{"a": 1}`, map[string]string{"a.b": "1"}, nil); err == nil {
+		t.Error("expected error when setting a key of a non-object")
+	}
+	if _, err := EditContent("json", `{}`, map[string]string{"a": "not json"}, nil); err == nil {
+		t.Error("expected error for value which is not JSON encoded")
+	}
+	if _, err := EditContent("text", "", nil, nil); err == nil {
+		t.Error("expected error for text content")
+	}
+}
+
+func TestGetContentKeys(t *testing.T) {
+	got, err := GetContentKeys("properties", "a=1\nb = x\\ty\nc:3\n", []string{"a", "b", "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"a": "1", "b": "x\ty"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	got, err = GetContentKeys("yaml", "server:\n  port: 8080\n  tags: [a, b]\n", []string{"server.port", "server.tags", "server.host"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"server.port": "8080", "server.tags": `["a","b"]`}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestNormalizeContentKeys(t *testing.T) {
+	configured := map[string]string{
+		"ratio":   "1.0",
+		"server":  `{ "port": 8080 }`,
+		"date":    `"2024-01-01"`,
+		"changed": "1",
+	}
+	for _, contentType := range []string{"json", "yaml"} {
+		content, err := EditContent(contentType, "", configured, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Changed outside of Terraform.
+		content, err = EditContent(contentType, content, map[string]string{"changed": "2"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := GetContentKeys(contentType, content, sortedKeys(configured))
+		if err != nil {
+			t.Fatal(err)
+		}
+		NormalizeContentKeys(contentType, got, configured)
+		want := map[string]string{"ratio": "1.0", "server": `{ "port": 8080 }`, "date": `"2024-01-01"`, "changed": "2"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected %v, got %v", contentType, want, got)
+		}
+	}
+
+	got := map[string]string{"a": "1"}
+	NormalizeContentKeys("properties", got, map[string]string{"a": "1.0"})
+	if got["a"] != "1" {
+		t.Errorf("expected properties value to be kept, got %q", got["a"])
+	}
+}
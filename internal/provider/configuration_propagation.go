@@ -0,0 +1,145 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+)
+
+// defaultPropagationTimeout is the timeout of wait_for_propagation when
+// none is configured.
+const defaultPropagationTimeout = 5 * time.Minute
+
+// propagationPollInterval is the interval between listener queries.
+var propagationPollInterval = 2 * time.Second
+
+// WaitForPropagationModel describes the wait_for_propagation block.
+type WaitForPropagationModel struct {
+	Timeout       types.String  `tfsdk:"timeout"`
+	MinFraction   types.Float64 `tfsdk:"min_fraction"`
+	FailOnTimeout types.Bool    `tfsdk:"fail_on_timeout"`
+}
+
+// TimeoutValue returns the configured timeout, or the default one.
+func (w *WaitForPropagationModel) TimeoutValue() (time.Duration, error) {
+	if w.Timeout.IsNull() {
+		return defaultPropagationTimeout, nil
+	}
+	timeout, err := time.ParseDuration(w.Timeout.ValueString())
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("timeout must be positive, got %s", w.Timeout.ValueString())
+	}
+	return timeout, nil
+}
+
+// MinFractionValue returns the configured minimum fraction, default is all
+// listeners.
+func (w *WaitForPropagationModel) MinFractionValue() float64 {
+	if w.MinFraction.IsNull() {
+		return 1
+	}
+	return w.MinFraction.ValueFloat64()
+}
+
+// laggingListeners returns the sorted ips of the listeners which do not hold
+// the md5.
+func laggingListeners(listeners []*Listener, md5 string) []string {
+	lagging := []string{}
+	for _, listener := range listeners {
+		if listener.Md5 != md5 {
+			lagging = append(lagging, listener.IP)
+		}
+	}
+	sort.Strings(lagging)
+	return lagging
+}
+
+// listenerIPs returns the sorted ips of the listeners.
+func listenerIPs(listeners []*Listener) []string {
+	ips := make([]string, 0, len(listeners))
+	for _, listener := range listeners {
+		ips = append(ips, listener.IP)
+	}
+	sort.Strings(ips)
+	return ips
+}
+
+// propagated reports whether at least the fraction of listeners are up to
+// date, which is true when there are no listeners.
+func propagated(total, lagging int, minFraction float64) bool {
+	if total == 0 {
+		return true
+	}
+	return float64(total-lagging)/float64(total) >= minFraction
+}
+
+// waitForPropagation polls the listeners of the configuration until enough
+// of them hold the md5. When the timeout is reached the lagging listeners
+// are reported as error, or as warning if fail_on_timeout is false.
+func waitForPropagation(ctx context.Context, api *NacosAPI, opts *nacos.GetCfgOpts, md5 string, wait *WaitForPropagationModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	timeout, err := wait.TimeoutValue()
+	if err != nil {
+		diags.AddError(
+			"Invalid propagation timeout",
+			err.Error(),
+		)
+		return diags
+	}
+	minFraction := wait.MinFractionValue()
+	deadline := time.Now().Add(timeout)
+	for {
+		listeners, err := api.ListConfigListeners(ctx, opts)
+		if err != nil {
+			diags.AddError(
+				"Unable to read configuration listeners",
+				err.Error(),
+			)
+			return diags
+		}
+		lagging := laggingListeners(listeners, md5)
+		tflog.Debug(ctx, "waiting for configuration propagation", map[string]any{
+			"namespace_id": opts.NamespaceID,
+			"group":        opts.Group,
+			"data_id":      opts.DataID,
+			"listeners":    len(listeners),
+			"lagging":      len(lagging),
+		})
+		if propagated(len(listeners), len(lagging), minFraction) {
+			return diags
+		}
+		if time.Now().After(deadline) {
+			summary := "Configuration not propagated"
+			detail := fmt.Sprintf("%d of %d listeners of configuration %q in group %q did not receive md5 %s within %s: %s",
+				len(lagging), len(listeners), opts.DataID, opts.Group, md5, timeout, strings.Join(lagging, ", "))
+			if wait.FailOnTimeout.IsNull() || wait.FailOnTimeout.ValueBool() {
+				diags.AddError(summary, detail)
+			} else {
+				diags.AddWarning(summary, detail)
+			}
+			return diags
+		}
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"Configuration not propagated",
+				fmt.Sprintf("Waiting for propagation was cancelled: %s", ctx.Err()),
+			)
+			return diags
+		case <-time.After(propagationPollInterval):
+		}
+	}
+}
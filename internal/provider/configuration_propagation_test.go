@@ -0,0 +1,64 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPropagation(t *testing.T) {
+	listeners := []*Listener{
+		{IP: "10.0.0.3", Md5: "old"},
+		{IP: "10.0.0.1", Md5: "new"},
+		{IP: "10.0.0.2", Md5: "old"},
+		{IP: "10.0.0.4", Md5: "new"},
+	}
+	lagging := laggingListeners(listeners, "new")
+	if want := []string{"10.0.0.2", "10.0.0.3"}; !reflect.DeepEqual(lagging, want) {
+		t.Errorf("expected lagging %v, got %v", want, lagging)
+	}
+	if got, want := listenerIPs(listeners), []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected ips %v, got %v", want, got)
+	}
+	for _, c := range []struct {
+		total, lagging int
+		minFraction    float64
+		want           bool
+	}{
+		{0, 0, 1, true},
+		{4, 0, 1, true},
+		{4, 1, 1, false},
+		{4, 1, 0.75, true},
+		{4, 2, 0.75, false},
+		{4, 4, 0, true},
+	} {
+		if got := propagated(c.total, c.lagging, c.minFraction); got != c.want {
+			t.Errorf("propagated(%d, %d, %v): expected %v, got %v", c.total, c.lagging, c.minFraction, c.want, got)
+		}
+	}
+}
+
+func TestWaitForPropagationModel(t *testing.T) {
+	wait := &WaitForPropagationModel{Timeout: types.StringNull(), MinFraction: types.Float64Null()}
+	if timeout, err := wait.TimeoutValue(); err != nil || timeout != defaultPropagationTimeout {
+		t.Errorf("expected default timeout, got %s, %v", timeout, err)
+	}
+	if got := wait.MinFractionValue(); got != 1 {
+		t.Errorf("expected default min fraction 1, got %v", got)
+	}
+	wait.Timeout = types.StringValue("90s")
+	if timeout, err := wait.TimeoutValue(); err != nil || timeout != 90*time.Second {
+		t.Errorf("expected 90s, got %s, %v", timeout, err)
+	}
+	for _, timeout := range []string{"5", "-1m", "0s"} {
+		wait.Timeout = types.StringValue(timeout)
+		if _, err := wait.TimeoutValue(); err == nil {
+			t.Errorf("%s: expected error", timeout)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+)
+
+// Conflict policies for publishing configurations which already exist, with
+// the semantics of the Nacos console import.
+const (
+	ConflictPolicyAbort     = "ABORT"
+	ConflictPolicySkip      = "SKIP"
+	ConflictPolicyOverwrite = "OVERWRITE"
+)
+
+// listConfigurations returns the configurations of a namespace, only those
+// of the group and data id when they are not empty.
+func listConfigurations(ctx context.Context, client *nacos.Client, namespaceId, group, dataId string) (*nacos.ConfigurationList, error) {
+	tflog.Debug(ctx, "listing configurations", map[string]any{
+		"namespace_id": namespaceId,
+		"group":        group,
+		"data_id":      dataId,
+	})
+	if dataId == "" {
+		return client.ListConfigInNs(ctx, namespaceId, group)
+	}
+	return client.ListConfig(ctx, &nacos.ListCfgOpts{DataID: dataId, Group: group, NamespaceID: namespaceId})
+}
+
+// TransferResult is the outcome of publishing a set of configurations, each
+// item is a configuration id. Overwritten holds the succeeded configurations
+// which already existed before they were published.
+type TransferResult struct {
+	Succeeded   []string
+	Overwritten []string
+	Skipped     []string
+	Failed      []string
+}
+
+// Created returns the succeeded configurations which did not exist before.
+func (t *TransferResult) Created() []string {
+	created := []string{}
+	for _, id := range t.Succeeded {
+		if !containsString(t.Overwritten, id) {
+			created = append(created, id)
+		}
+	}
+	return created
+}
+
+// SetLists stores the result in the given list attributes.
+func (t *TransferResult) SetLists(ctx context.Context, succeeded, skipped, failed *types.List) diag.Diagnostics {
+	return setStringLists(ctx, []stringList{
+		{succeeded, t.Succeeded},
+		{skipped, t.Skipped},
+		{failed, t.Failed},
+	})
+}
+
+// stringList pairs a list attribute with the ids to store in it.
+type stringList struct {
+	target *types.List
+	ids    []string
+}
+
+func setStringLists(ctx context.Context, lists []stringList) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, item := range lists {
+		list, d := types.ListValueFrom(ctx, types.StringType, item.ids)
+		diags.Append(d...)
+		*item.target = list
+	}
+	return diags
+}
+
+// publishConfigurations publishes the configurations into the namespace,
+// configurations which already exist are handled according to the conflict
+// policy. Content with an encrypted data key is published as it is, other
+// content is encrypted when the data id is a cipher data id. Failures are
+// reported as warnings, operation names what is done in their summaries.
+func publishConfigurations(ctx context.Context, client *nacos.Client, api *NacosAPI, cipher *ConfigurationCipher, namespaceId, policy, operation string, configs []*nacos.Configuration) (*TransferResult, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	result := &TransferResult{Succeeded: []string{}, Overwritten: []string{}, Skipped: []string{}, Failed: []string{}}
+	failure := fmt.Sprintf("Unable to %s configuration", operation)
+	for i, cfg := range configs {
+		group := cfg.GetGroup()
+		id := BuildThreePartID(namespaceId, group, cfg.DataID)
+		_, err := client.GetConfig(ctx, &nacos.GetCfgOpts{DataID: cfg.DataID, Group: group, NamespaceID: namespaceId})
+		if err != nil && !IsNotFoundError(err) {
+			result.Failed = append(result.Failed, id)
+			diags.AddWarning(failure, fmt.Sprintf("Unable to read configuration %s: %s", id, err.Error()))
+			continue
+		}
+		exists := err == nil
+		if exists {
+			if policy == ConflictPolicySkip {
+				result.Skipped = append(result.Skipped, id)
+				continue
+			}
+			if policy == ConflictPolicyAbort {
+				result.Failed = append(result.Failed, id)
+				for _, rest := range configs[i+1:] {
+					result.Skipped = append(result.Skipped, BuildThreePartID(namespaceId, rest.GetGroup(), rest.DataID))
+				}
+				diags.AddWarning(
+					fmt.Sprintf("Configuration %s aborted", operation),
+					fmt.Sprintf("Configuration %s already exists, the remaining configurations were skipped. Use policy SKIP or OVERWRITE to %s them.", id, operation),
+				)
+				break
+			}
+		}
+		opts := &PublishCfgOpts{
+			DataID:           cfg.DataID,
+			Group:            group,
+			NamespaceID:      namespaceId,
+			Content:          cfg.Content,
+			EncryptedDataKey: cfg.EncryptedDataKey,
+			Type:             cfg.Type,
+			Application:      cfg.Application,
+			Description:      cfg.Description,
+			Tags:             cfg.Tags,
+		}
+		if opts.EncryptedDataKey == "" {
+			if err := cipher.EncryptConfig(ctx, opts); err != nil {
+				result.Failed = append(result.Failed, id)
+				diags.AddWarning(failure, fmt.Sprintf("Unable to encrypt configuration %s: %s", id, err.Error()))
+				continue
+			}
+		}
+		tflog.Debug(ctx, "publishing configuration", map[string]any{
+			"namespace_id": namespaceId,
+			"group":        group,
+			"data_id":      cfg.DataID,
+			"operation":    operation,
+		})
+		if err := api.PublishConfig(ctx, opts); err != nil {
+			result.Failed = append(result.Failed, id)
+			diags.AddWarning(failure, fmt.Sprintf("Unable to publish configuration %s: %s", id, err.Error()))
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, id)
+		if exists {
+			result.Overwritten = append(result.Overwritten, id)
+		}
+	}
+	return result, diags
+}
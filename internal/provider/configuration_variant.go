@@ -0,0 +1,38 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/joelee2012/go-nacos"
+)
+
+// ConfigurationVariantModel describes the attributes shared by the resources
+// publishing a variant of a configuration, such as a beta, a gray rule or a
+// tag variant. It is embedded in their resource data models.
+type ConfigurationVariantModel struct {
+	DataID      types.String `tfsdk:"data_id"`
+	Group       types.String `tfsdk:"group"`
+	NamespaceID types.String `tfsdk:"namespace_id"`
+	Content     types.String `tfsdk:"content"`
+	Type        types.String `tfsdk:"type"`
+	Md5         types.String `tfsdk:"md5"`
+}
+
+func (c *ConfigurationVariantModel) SetFromConfiguration(cfg *nacos.Configuration) {
+	c.DataID = types.StringValue(cfg.DataID)
+	c.Group = types.StringValue(cfg.GetGroup())
+	// The public namespace is reported as either "" or "public" depending on
+	// the server version, keep it as configured.
+	if !isPublicNamespace(cfg.GetNamespace()) || !(c.NamespaceID.IsNull() || isPublicNamespace(c.NamespaceID.ValueString())) {
+		c.NamespaceID = types.StringValue(cfg.GetNamespace())
+	}
+	c.Content = types.StringValue(cfg.Content)
+	c.Type = types.StringValue(cfg.Type)
+	c.Md5 = types.StringValue(cfg.Md5)
+}
+
+func isPublicNamespace(namespaceID string) bool {
+	return namespaceID == "" || namespaceID == "public"
+}
@@ -21,6 +21,7 @@ func NewConfigurationDataSource() datasource.DataSource {
 // ConfigurationDataSource defines the data source implementation.
 type ConfigurationDataSource struct {
 	client *nacos.Client
+	cipher *ConfigurationCipher
 }
 
 // ConfigurationDataSourceModel describes the data source data model.
@@ -38,6 +39,8 @@ type ConfigurationDataSourceModel struct {
 	ModifyTime       types.Int64  `tfsdk:"modify_time"`
 	Description      types.String `tfsdk:"description"`
 	Tags             types.Set    `tfsdk:"tags"`
+	Sensitive        types.Bool   `tfsdk:"sensitive"`
+	SensitiveContent types.String `tfsdk:"sensitive_content"`
 }
 
 func (d *ConfigurationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -67,9 +70,18 @@ func (d *ConfigurationDataSource) Schema(ctx context.Context, req datasource.Sch
 				Computed:            true,
 			},
 			"content": schema.StringAttribute{
-				MarkdownDescription: "Configuration content.",
+				MarkdownDescription: "Configuration content, empty when `sensitive` is `true`.",
 				Computed:            true,
 			},
+			"sensitive": schema.BoolAttribute{
+				MarkdownDescription: "Return the configuration content in `sensitive_content` instead of `content`.",
+				Optional:            true,
+			},
+			"sensitive_content": schema.StringAttribute{
+				MarkdownDescription: "Configuration content when `sensitive` is `true`.",
+				Computed:            true,
+				Sensitive:           true,
+			},
 			"type": schema.StringAttribute{
 				MarkdownDescription: "Configuration type.",
 				Computed:            true,
@@ -112,18 +124,19 @@ func (d *ConfigurationDataSource) Configure(ctx context.Context, req datasource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*nacos.Client)
+	providerData, ok := req.ProviderData.(*NacosProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *nacos.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
+	d.cipher = providerData.Cipher
 }
 
 func (d *ConfigurationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -149,12 +162,24 @@ func (d *ConfigurationDataSource) Read(ctx context.Context, req datasource.ReadR
 		}
 		return
 	}
+	// Without an encryption key the content is returned as stored on the server.
+	content := cfg.Content
+	if d.cipher != nil {
+		content, err = d.cipher.DecryptContent(ctx, cfg.DataID, cfg.Content, cfg.EncryptedDataKey)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to decrypt configuration",
+				err.Error(),
+			)
+			return
+		}
+	}
 
 	data = ConfigurationDataSourceModel{
 		ID:               types.StringValue(BuildThreePartID(cfg.NamespaceID, cfg.Group, cfg.DataID)),
 		DataID:           types.StringValue(cfg.DataID),
 		Group:            types.StringValue(cfg.GetGroup()),
-		Content:          types.StringValue(cfg.Content),
+		Content:          types.StringValue(content),
 		NamespaceID:      types.StringValue(cfg.GetNamespace()),
 		Type:             types.StringValue(cfg.Type),
 		Md5:              types.StringValue(cfg.Md5),
@@ -163,6 +188,12 @@ func (d *ConfigurationDataSource) Read(ctx context.Context, req datasource.ReadR
 		CreateTime:       types.Int64Value(cfg.CreateTime),
 		ModifyTime:       types.Int64Value(cfg.ModifyTime),
 		Description:      types.StringValue(cfg.Description),
+		Sensitive:        data.Sensitive,
+		SensitiveContent: types.StringNull(),
+	}
+	if data.Sensitive.ValueBool() {
+		data.SensitiveContent = data.Content
+		data.Content = types.StringNull()
 	}
 	if cfg.Tags != "" {
 		tags, diags := types.SetValueFrom(ctx, types.StringType, strings.Split(cfg.Tags, ","))
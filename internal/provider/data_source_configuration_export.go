@@ -0,0 +1,268 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/joelee2012/go-nacos"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConfigurationExportDataSource{}
+
+func NewConfigurationExportDataSource() datasource.DataSource {
+	return &ConfigurationExportDataSource{}
+}
+
+// ConfigurationExportDataSource defines the data source implementation.
+type ConfigurationExportDataSource struct {
+	client *nacos.Client
+	cipher *ConfigurationCipher
+}
+
+// ConfigurationExportDataSourceModel describes the data source data model.
+type ConfigurationExportDataSourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	NamespaceID        types.String `tfsdk:"namespace_id"`
+	Group              types.String `tfsdk:"group"`
+	DataID             types.String `tfsdk:"data_id"`
+	IDs                types.List   `tfsdk:"ids"`
+	MetadataFormat     types.String `tfsdk:"metadata_format"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	ContentBase64      types.String `tfsdk:"content_base64"`
+	Sha256             types.String `tfsdk:"sha256"`
+	ConfigurationCount types.Int64  `tfsdk:"configuration_count"`
+}
+
+func (d *ConfigurationExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_export"
+}
+
+func (d *ConfigurationExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Exports the configurations of a namespace to a ZIP archive in the layout of the Nacos console export." +
+			" Configurations with a `cipher-` data id are exported decrypted, without the provider `encryption_key` they are left out with a warning.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The sha256 of the archive.",
+				Computed:            true,
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Namespace to export, default is empty string which means public namespace.",
+				Optional:            true,
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Only export configurations of this group.",
+				Optional:            true,
+			},
+			"data_id": schema.StringAttribute{
+				MarkdownDescription: "Only export configurations with this data id.",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "Only export these configurations, in the format of `<namespace_id>:<group>:<data_id>`. " +
+					"All of them must belong to `namespace_id` and exist.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"metadata_format": schema.StringAttribute{
+				MarkdownDescription: "Format of the metadata file, `v2` writes `.metadata.yml` as the Nacos 2.x console, " +
+					"`v1` writes `.meta.yml` as the Nacos 1.x console. Default is `v2`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ExportMetadataV2, ExportMetadataV1),
+				},
+			},
+			"output_path": schema.StringAttribute{
+				MarkdownDescription: "Write the archive to this local path instead of returning it in `content_base64`.",
+				Optional:            true,
+			},
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "The archive encoded in base64, empty when `output_path` is set.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "The hex encoded sha256 of the archive.",
+				Computed:            true,
+			},
+			"configuration_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of exported configurations.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ConfigurationExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.cipher = providerData.Cipher
+}
+
+func (d *ConfigurationExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConfigurationExportDataSourceModel
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespaceId := data.NamespaceID.ValueString()
+	// The wanted configurations keyed by `<group>:<data_id>`, nil exports all.
+	var wanted map[string]bool
+	if !data.IDs.IsNull() {
+		var ids []string
+		resp.Diagnostics.Append(data.IDs.ElementsAs(ctx, &ids, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		wanted = make(map[string]bool, len(ids))
+		for _, id := range ids {
+			ns, group, dataId, err := ParseThreePartID(id)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("ids"),
+					"Unable to parse configuration id",
+					err.Error(),
+				)
+				return
+			}
+			if ns != namespaceId && !(isPublicNamespace(ns) && isPublicNamespace(namespaceId)) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("ids"),
+					"Configuration not in exported namespace",
+					fmt.Sprintf("Configuration %s does not belong to namespace %q.", id, namespaceId),
+				)
+				return
+			}
+			wanted[group+":"+dataId] = false
+		}
+	}
+
+	list, err := listConfigurations(ctx, d.client, namespaceId, data.Group.ValueString(), data.DataID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read configurations",
+			err.Error(),
+		)
+		return
+	}
+
+	var configs []*nacos.Configuration
+	var skipped []string
+	for _, cfg := range list.Items {
+		if wanted != nil {
+			key := cfg.GetGroup() + ":" + cfg.DataID
+			if _, ok := wanted[key]; !ok {
+				continue
+			}
+			wanted[key] = true
+		}
+		// The archive holds the plain content and no encrypted data key, so
+		// encrypted content could not be imported again.
+		if IsCipherDataID(cfg.DataID) && d.cipher == nil {
+			id := BuildThreePartID(namespaceId, cfg.GetGroup(), cfg.DataID)
+			if wanted != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("ids"),
+					"Unable to export encrypted configuration",
+					fmt.Sprintf("Configuration %s is encrypted, set the provider encryption_key to export it.", id),
+				)
+				return
+			}
+			skipped = append(skipped, id)
+			continue
+		}
+		cfg.Content, err = d.cipher.DecryptContent(ctx, cfg.DataID, cfg.Content, cfg.EncryptedDataKey)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to decrypt configuration",
+				fmt.Sprintf("Unable to decrypt configuration %s: %s", BuildThreePartID(namespaceId, cfg.GetGroup(), cfg.DataID), err.Error()),
+			)
+			return
+		}
+		configs = append(configs, cfg)
+	}
+	if len(skipped) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Encrypted configurations not exported",
+			fmt.Sprintf("Configurations %s are encrypted and were left out of the archive, set the provider encryption_key to export them.", strings.Join(skipped, ", ")),
+		)
+	}
+	var missing []string
+	for key, found := range wanted {
+		if !found {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ids"),
+			"Configuration not found",
+			fmt.Sprintf("Configurations %s (<group>:<data_id>) do not exist in namespace %q.", strings.Join(missing, ", "), namespaceId),
+		)
+		return
+	}
+
+	archive, err := BuildConfigurationArchive(configs, data.MetadataFormat.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to build configuration archive",
+			err.Error(),
+		)
+		return
+	}
+	sum := sha256.Sum256(archive)
+	data.Sha256 = types.StringValue(hex.EncodeToString(sum[:]))
+	data.ID = data.Sha256
+	data.ConfigurationCount = types.Int64Value(int64(len(configs)))
+	data.ContentBase64 = types.StringValue("")
+	if data.OutputPath.IsNull() {
+		data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(archive))
+	} else if err := os.WriteFile(data.OutputPath.ValueString(), archive, 0o600); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("output_path"),
+			"Unable to write configuration archive",
+			err.Error(),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
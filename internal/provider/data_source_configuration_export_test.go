@@ -0,0 +1,93 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
+)
+
+func TestAccConfigurationExportDataSource(t *testing.T) {
+	resourceName := "data.nacos_configuration_export.test"
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: "export-test.yaml", Group: "export-group", Content: "a: 1", Type: "yaml"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "nacos_configuration_export" "test" {
+  group = "export-group"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("configuration_count"),
+						knownvalue.Int64Exact(1),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("sha256"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					),
+				},
+			},
+			{
+				Config: `
+data "nacos_configuration_export" "test" {
+  ids = [":export-group:not-exist.yaml"]
+}
+`,
+				ExpectError: regexp.MustCompile("Configuration not found"),
+			},
+		},
+	})
+}
+
+func TestAccConfigurationExportDataSource_encrypted(t *testing.T) {
+	if os.Getenv("NACOS_ENCRYPTION_KEY") != "" {
+		t.Skip("encrypted configurations are only left out without an encryption key")
+	}
+	resourceName := "data.nacos_configuration_export.test"
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: "export-test.yaml", Group: "export-cipher-group", Content: "a: 1", Type: "yaml"})
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: "cipher-aes-export-test.yaml", Group: "export-cipher-group", Content: "encrypted", Type: "yaml"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "nacos_configuration_export" "test" {
+  group = "export-cipher-group"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("configuration_count"),
+						knownvalue.Int64Exact(1),
+					),
+				},
+			},
+			{
+				Config: `
+data "nacos_configuration_export" "test" {
+  ids = [":export-cipher-group:cipher-aes-export-test.yaml"]
+}
+`,
+				ExpectError: regexp.MustCompile("Unable to export encrypted configuration"),
+			},
+		},
+	})
+}
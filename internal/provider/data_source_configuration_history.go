@@ -0,0 +1,327 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// historyPageSize is the number of revisions requested per page.
+const historyPageSize = 100
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConfigurationHistoryDataSource{}
+
+func NewConfigurationHistoryDataSource() datasource.DataSource {
+	return &ConfigurationHistoryDataSource{}
+}
+
+// ConfigurationHistoryDataSource defines the data source implementation.
+type ConfigurationHistoryDataSource struct {
+	api    *NacosAPI
+	cipher *ConfigurationCipher
+}
+
+// ConfigurationHistoryDataSourceModel describes the data source data model.
+type ConfigurationHistoryDataSourceModel struct {
+	ID             types.String                  `tfsdk:"id"`
+	DataID         types.String                  `tfsdk:"data_id"`
+	Group          types.String                  `tfsdk:"group"`
+	NamespaceID    types.String                  `tfsdk:"namespace_id"`
+	StartTime      types.Int64                   `tfsdk:"start_time"`
+	EndTime        types.Int64                   `tfsdk:"end_time"`
+	SrcUser        types.String                  `tfsdk:"src_user"`
+	OpType         types.String                  `tfsdk:"op_type"`
+	Limit          types.Int64                   `tfsdk:"limit"`
+	IncludeContent types.Bool                    `tfsdk:"include_content"`
+	Sensitive      types.Bool                    `tfsdk:"sensitive"`
+	Revisions      []*ConfigurationRevisionModel `tfsdk:"revisions"`
+}
+
+type ConfigurationRevisionModel struct {
+	Nid              types.Int64  `tfsdk:"nid"`
+	OpType           types.String `tfsdk:"op_type"`
+	SrcUser          types.String `tfsdk:"src_user"`
+	SrcIP            types.String `tfsdk:"src_ip"`
+	Md5              types.String `tfsdk:"md5"`
+	Content          types.String `tfsdk:"content"`
+	SensitiveContent types.String `tfsdk:"sensitive_content"`
+	CreateTime       types.Int64  `tfsdk:"create_time"`
+	ModifyTime       types.Int64  `tfsdk:"modify_time"`
+}
+
+// PastStartTime reports whether the revision was modified before start_time.
+// Revisions are listed newest first, so no later revision matches either.
+func (d *ConfigurationHistoryDataSourceModel) PastStartTime(h *ConfigurationHistory) bool {
+	return !d.StartTime.IsNull() && h.ModifyTime < d.StartTime.ValueInt64()
+}
+
+// Matches reports whether the revision passes the filters of the model,
+// start_time is checked by PastStartTime.
+func (d *ConfigurationHistoryDataSourceModel) Matches(h *ConfigurationHistory) bool {
+	if !d.EndTime.IsNull() && h.ModifyTime > d.EndTime.ValueInt64() {
+		return false
+	}
+	if !d.SrcUser.IsNull() && h.SrcUser != d.SrcUser.ValueString() {
+		return false
+	}
+	if !d.OpType.IsNull() && h.OpType != d.OpType.ValueString() {
+		return false
+	}
+	return true
+}
+
+func (d *ConfigurationHistoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_history"
+}
+
+func (d *ConfigurationHistoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Revision history of a configuration, newest revision first. The content and md5 of the revisions are only read when `include_content` is `true`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource. In the format of `<namespace_id>:<group>:<data_id>`.",
+				Computed:            true,
+			},
+			"data_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration data id.",
+				Required:            true,
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Configuration group.",
+				Required:            true,
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration namespace id.",
+				Optional:            true,
+			},
+			"start_time": schema.Int64Attribute{
+				MarkdownDescription: "Only return revisions modified at or after this time, in milliseconds since the epoch.",
+				Optional:            true,
+			},
+			"end_time": schema.Int64Attribute{
+				MarkdownDescription: "Only return revisions modified at or before this time, in milliseconds since the epoch.",
+				Optional:            true,
+			},
+			"src_user": schema.StringAttribute{
+				MarkdownDescription: "Only return revisions made by this user.",
+				Optional:            true,
+			},
+			"op_type": schema.StringAttribute{
+				MarkdownDescription: "Only return revisions of this operation type, one of `I` (insert), `U` (update) or `D` (delete).",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("I", "U", "D"),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of revisions to return, between 1 and 500. When not set all matching revisions are returned, and `limit` is set to their number.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 500),
+				},
+			},
+			"include_content": schema.BoolAttribute{
+				MarkdownDescription: "Read the content and md5 of each revision, which takes one request per revision. Default is `false`.",
+				Optional:            true,
+			},
+			"sensitive": schema.BoolAttribute{
+				MarkdownDescription: "Return the revision content in `sensitive_content` instead of `content` of each revision.",
+				Optional:            true,
+			},
+			"revisions": schema.ListNestedAttribute{
+				MarkdownDescription: "Revisions of the configuration, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"nid": schema.Int64Attribute{
+							MarkdownDescription: "Revision id.",
+							Computed:            true,
+						},
+						"op_type": schema.StringAttribute{
+							MarkdownDescription: "Operation type of the revision, `I` (insert), `U` (update) or `D` (delete).",
+							Computed:            true,
+						},
+						"src_user": schema.StringAttribute{
+							MarkdownDescription: "User who made the revision.",
+							Computed:            true,
+						},
+						"src_ip": schema.StringAttribute{
+							MarkdownDescription: "IP address the revision was made from.",
+							Computed:            true,
+						},
+						"md5": schema.StringAttribute{
+							MarkdownDescription: "Configuration md5 of the revision, only set when `include_content` is `true`.",
+							Computed:            true,
+						},
+						"content": schema.StringAttribute{
+							MarkdownDescription: "Configuration content of the revision, only set when `include_content` is `true` and `sensitive` is not.",
+							Computed:            true,
+						},
+						"sensitive_content": schema.StringAttribute{
+							MarkdownDescription: "Configuration content of the revision when `include_content` and `sensitive` are `true`.",
+							Computed:            true,
+							Sensitive:           true,
+						},
+						"create_time": schema.Int64Attribute{
+							MarkdownDescription: "Revision created time.",
+							Computed:            true,
+						},
+						"modify_time": schema.Int64Attribute{
+							MarkdownDescription: "Revision modify time.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ConfigurationHistoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.api = providerData.API
+	d.cipher = providerData.Cipher
+}
+
+func (d *ConfigurationHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConfigurationHistoryDataSourceModel
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without a limit the whole history is read.
+	limit := int(data.Limit.ValueInt64())
+	if data.Limit.IsNull() {
+		limit = math.MaxInt
+	}
+
+	opts := &ListHistoryOpts{
+		DataID:      data.DataID.ValueString(),
+		Group:       data.Group.ValueString(),
+		NamespaceID: data.NamespaceID.ValueString(),
+		PageSize:    historyPageSize,
+	}
+	var matched []*ConfigurationHistory
+	for opts.PageNo = 1; len(matched) < limit; opts.PageNo++ {
+		tflog.Debug(ctx, "listing configuration history", map[string]any{
+			"namespace_id": opts.NamespaceID,
+			"group":        opts.Group,
+			"data_id":      opts.DataID,
+			"page_no":      opts.PageNo,
+		})
+		page, err := d.api.ListHistory(ctx, opts)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read configuration history",
+				err.Error(),
+			)
+			return
+		}
+		pastStartTime := false
+		for _, h := range page.Items {
+			if data.PastStartTime(h) {
+				pastStartTime = true
+				break
+			}
+			if data.Matches(h) {
+				matched = append(matched, h)
+			}
+		}
+		if pastStartTime || len(page.Items) == 0 || opts.PageNo >= page.PagesAvailable {
+			break
+		}
+	}
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	if data.Limit.IsNull() {
+		data.Limit = types.Int64Value(int64(len(matched)))
+	}
+
+	data.ID = types.StringValue(BuildThreePartID(opts.NamespaceID, opts.Group, opts.DataID))
+	data.Revisions = make([]*ConfigurationRevisionModel, 0, len(matched))
+	for _, h := range matched {
+		item := &ConfigurationRevisionModel{
+			Nid:              types.Int64Value(h.ID),
+			OpType:           types.StringValue(h.OpType),
+			SrcUser:          types.StringValue(h.SrcUser),
+			SrcIP:            types.StringValue(h.SrcIP),
+			Md5:              types.StringNull(),
+			Content:          types.StringNull(),
+			SensitiveContent: types.StringNull(),
+			CreateTime:       types.Int64Value(h.CreateTime),
+			ModifyTime:       types.Int64Value(h.ModifyTime),
+		}
+		if data.IncludeContent.ValueBool() {
+			// The history list does not include the content of the revisions.
+			revision, err := d.api.GetHistory(ctx, &GetHistoryOpts{
+				ID:          h.ID,
+				DataID:      opts.DataID,
+				Group:       opts.Group,
+				NamespaceID: opts.NamespaceID,
+			})
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to read configuration revision",
+					fmt.Sprintf("Unable to read revision %d: %s", h.ID, err.Error()),
+				)
+				return
+			}
+			// Without an encryption key the content is returned as stored on the server.
+			content := revision.Content
+			if d.cipher != nil {
+				content, err = d.cipher.DecryptContent(ctx, opts.DataID, revision.Content, revision.EncryptedDataKey)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to decrypt configuration revision",
+						fmt.Sprintf("Unable to decrypt revision %d: %s", h.ID, err.Error()),
+					)
+					return
+				}
+			}
+			item.Md5 = types.StringValue(revision.Md5)
+			if data.Sensitive.ValueBool() {
+				item.SensitiveContent = types.StringValue(content)
+			} else {
+				item.Content = types.StringValue(content)
+			}
+		}
+		data.Revisions = append(data.Revisions, item)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
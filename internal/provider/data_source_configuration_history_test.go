@@ -0,0 +1,96 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
+)
+
+func TestAccConfigurationHistoryDataSource(t *testing.T) {
+	resourceName := "data.nacos_configuration_history.test"
+	dataId := "test-history-data-id"
+	group := "test-group"
+	opts := &nacos.CreateCfgOpts{DataID: dataId, Group: group, Content: "version=1"}
+	setupTestConfiguration(t, opts)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					update := *opts
+					update.Content = "version=2"
+					if err := testClient.CreateConfig(context.Background(), &update); err != nil {
+						t.Fatalf("Error updating configuration: %s", err)
+					}
+				},
+				Config: fmt.Sprintf(`
+data "nacos_configuration_history" "test" {
+  data_id = "%s"
+  group   = "%s"
+  op_type = "U"
+}
+`, dataId, group),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("limit"),
+						knownvalue.Int64Exact(1),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("revisions").AtSliceIndex(0).AtMapKey("content"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("revisions"),
+						knownvalue.ListSizeExact(1),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("revisions").AtSliceIndex(0).AtMapKey("op_type"),
+						knownvalue.StringExact("U"),
+					),
+				},
+			},
+			{
+				Config: fmt.Sprintf(`
+data "nacos_configuration_history" "test" {
+  data_id = "%s"
+  group   = "%s"
+  limit           = 1
+  include_content = true
+}
+`, dataId, group),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("revisions"),
+						knownvalue.ListSizeExact(1),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("revisions").AtSliceIndex(0).AtMapKey("content"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("revisions").AtSliceIndex(0).AtMapKey("md5"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConfigurationListenersDataSource{}
+
+func NewConfigurationListenersDataSource() datasource.DataSource {
+	return &ConfigurationListenersDataSource{}
+}
+
+// ConfigurationListenersDataSource defines the data source implementation.
+type ConfigurationListenersDataSource struct {
+	api *NacosAPI
+}
+
+// ConfigurationListenersDataSourceModel describes the data source data model.
+type ConfigurationListenersDataSourceModel struct {
+	ID          types.String     `tfsdk:"id"`
+	DataID      types.String     `tfsdk:"data_id"`
+	Group       types.String     `tfsdk:"group"`
+	NamespaceID types.String     `tfsdk:"namespace_id"`
+	Listeners   []*ListenerModel `tfsdk:"listeners"`
+}
+
+type ListenerModel struct {
+	IP  types.String `tfsdk:"ip"`
+	Md5 types.String `tfsdk:"md5"`
+}
+
+func (d *ConfigurationListenersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_listeners"
+}
+
+func (d *ConfigurationListenersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Clients listening to a configuration, and the md5 of the content each one currently holds. " +
+			"Use `nacos_listened_configurations` to look up the configurations a client listens to.",
+
+		Attributes: map[string]schema.Attribute{
+			"data_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration data id.",
+				Required:            true,
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Configuration group.",
+				Required:            true,
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration namespace id.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource. In the format of `<namespace_id>:<group>:<data_id>`.",
+				Computed:            true,
+			},
+			"listeners": schema.ListNestedAttribute{
+				MarkdownDescription: "Listening clients, ordered by ip.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ip": schema.StringAttribute{
+							MarkdownDescription: "Client ip.",
+							Computed:            true,
+						},
+						"md5": schema.StringAttribute{
+							MarkdownDescription: "Md5 of the configuration content the client holds.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ConfigurationListenersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.api = providerData.API
+}
+
+func (d *ConfigurationListenersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConfigurationListenersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &nacos.GetCfgOpts{DataID: data.DataID.ValueString(), Group: data.Group.ValueString(), NamespaceID: data.NamespaceID.ValueString()}
+	tflog.Debug(ctx, "read configuration listeners", map[string]any{
+		"namespace_id": opts.NamespaceID,
+		"group":        opts.Group,
+		"data_id":      opts.DataID,
+	})
+	listeners, err := d.api.ListConfigListeners(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read configuration listeners",
+			err.Error(),
+		)
+		return
+	}
+	sort.Slice(listeners, func(i, j int) bool { return listeners[i].IP < listeners[j].IP })
+	data.ID = types.StringValue(BuildThreePartID(opts.NamespaceID, opts.Group, opts.DataID))
+	data.Listeners = make([]*ListenerModel, 0, len(listeners))
+	for _, listener := range listeners {
+		data.Listeners = append(data.Listeners, &ListenerModel{
+			IP:  types.StringValue(listener.IP),
+			Md5: types.StringValue(listener.Md5),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
+)
+
+func TestAccConfigurationListenersDataSource(t *testing.T) {
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: "listeners-test", Group: "DEFAULT_GROUP", Content: "a=1"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "nacos_configuration_listeners" "test" {
+  data_id = "listeners-test"
+  group   = "DEFAULT_GROUP"
+}
+
+data "nacos_listened_configurations" "test" {
+  ip = "192.0.2.1"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.nacos_configuration_listeners.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(":DEFAULT_GROUP:listeners-test"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.nacos_configuration_listeners.test",
+						tfjsonpath.New("listeners"),
+						knownvalue.ListSizeExact(0),
+					),
+					statecheck.ExpectKnownValue(
+						"data.nacos_listened_configurations.test",
+						tfjsonpath.New("configurations"),
+						knownvalue.ListSizeExact(0),
+					),
+				},
+			},
+		},
+	})
+}
@@ -56,3 +56,49 @@ data "nacos_configuration" "test" {
 		},
 	})
 }
+
+func TestAccConfigurationDataSource_sensitive(t *testing.T) {
+	resourceName := "data.nacos_configuration.test"
+	dataId := "test-sensitive-data-id"
+	group := "test-group"
+	content := "db.password=secret\n"
+	namespaceId := ""
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{NamespaceID: namespaceId, DataID: dataId, Group: group, Content: content})
+	if testClient != nil && testClient.APIVersion == "v3" {
+		namespaceId = "public"
+	}
+	config := fmt.Sprintf(`
+data "nacos_configuration" "test" {
+  data_id      = "%s"
+  group        = "%s"
+  namespace_id = "%s"
+  sensitive    = true
+}
+`, dataId, group, namespaceId)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectSensitiveValue(
+						resourceName,
+						tfjsonpath.New("sensitive_content"),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("sensitive_content"),
+						knownvalue.StringExact(content),
+					),
+				},
+			},
+		},
+	})
+}
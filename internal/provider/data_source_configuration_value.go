@@ -0,0 +1,386 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+	"gopkg.in/yaml.v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConfigurationValueDataSource{}
+
+func NewConfigurationValueDataSource() datasource.DataSource {
+	return &ConfigurationValueDataSource{}
+}
+
+// ConfigurationValueDataSource defines the data source implementation.
+type ConfigurationValueDataSource struct {
+	client *nacos.Client
+	cipher *ConfigurationCipher
+}
+
+// ConfigurationValueDataSourceModel describes the data source data model.
+type ConfigurationValueDataSourceModel struct {
+	ID          types.String  `tfsdk:"id"`
+	DataID      types.String  `tfsdk:"data_id"`
+	Group       types.String  `tfsdk:"group"`
+	NamespaceID types.String  `tfsdk:"namespace_id"`
+	Type        types.String  `tfsdk:"type"`
+	Key         types.String  `tfsdk:"key"`
+	Value       types.String  `tfsdk:"value"`
+	Document    types.Dynamic `tfsdk:"document"`
+	Md5         types.String  `tfsdk:"md5"`
+}
+
+func (d *ConfigurationValueDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_value"
+}
+
+func (d *ConfigurationValueDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Parses the content of a `properties`, `yaml` or `json` configuration and returns the value of a key, " +
+			"as well as the whole parsed document.",
+
+		Attributes: map[string]schema.Attribute{
+			"data_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration data id.",
+				Required:            true,
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Configuration group.",
+				Required:            true,
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration namespace id.",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Format of the content, one of `properties`, `yaml` and `json`. " +
+					"Default is the configuration type, or derived from the data id extension if the type is none of them.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("properties", "yaml", "json"),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Key to return the value of. For `properties` content this is a property key. " +
+					"For `yaml` and `json` content this is a path of object keys and array indexes, " +
+					"either dotted, e.g. `spring.datasource.url` or `servers[0].host`, or JSONPath, e.g. `$.servers[0]['host.name']`.",
+				Optional: true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Value of `key`, objects and arrays are JSON encoded. Null when `key` is not set or the value is null.",
+				Computed:            true,
+			},
+			"document": schema.DynamicAttribute{
+				MarkdownDescription: "The parsed content. Values of `properties` content are strings.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource. In the format of `<namespace_id>:<group>:<data_id>`.",
+				Computed:            true,
+			},
+			"md5": schema.StringAttribute{
+				MarkdownDescription: "Configuration md5.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ConfigurationValueDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.cipher = providerData.Cipher
+}
+
+func (d *ConfigurationValueDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConfigurationValueDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &nacos.GetCfgOpts{DataID: data.DataID.ValueString(), Group: data.Group.ValueString(), NamespaceID: data.NamespaceID.ValueString()}
+	tflog.Debug(ctx, "read configuration value", map[string]any{
+		"namespace_id": opts.NamespaceID,
+		"group":        opts.Group,
+		"data_id":      opts.DataID,
+		"key":          data.Key.ValueString(),
+	})
+	cfg, err := d.client.GetConfig(ctx, opts)
+	if err != nil {
+		if IsNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"Configuration not found",
+				fmt.Sprintf("Configuration with namespace_id=%s, group=%s, data_id=%s does not exist.", opts.NamespaceID, opts.Group, opts.DataID),
+			)
+		} else {
+			resp.Diagnostics.AddError(
+				"Unable to read configuration",
+				err.Error(),
+			)
+		}
+		return
+	}
+	content, err := d.cipher.DecryptContent(ctx, cfg.DataID, cfg.Content, cfg.EncryptedDataKey)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to decrypt configuration",
+			err.Error(),
+		)
+		return
+	}
+
+	contentType := data.Type.ValueString()
+	if data.Type.IsNull() || data.Type.IsUnknown() {
+		contentType = contentTypeOf(cfg)
+	}
+	var doc *yaml.Node
+	switch contentType {
+	case "properties":
+		doc = parsePropertiesNode(content)
+	case "json", "yaml":
+		doc, err = parseContentNode(content)
+	default:
+		err = fmt.Errorf("unable to parse content of type %q, expected one of json, yaml, properties", contentType)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse configuration",
+			fmt.Sprintf("Unable to parse configuration %q in group %q: %s", opts.DataID, opts.Group, err.Error()),
+		)
+		return
+	}
+	document, err := nodeToValue(doc.Content[0])
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse configuration",
+			fmt.Sprintf("Unable to parse configuration %q in group %q: %s", opts.DataID, opts.Group, err.Error()),
+		)
+		return
+	}
+
+	data.Value = types.StringNull()
+	if !data.Key.IsNull() {
+		var node *yaml.Node
+		if contentType == "properties" {
+			node = lookupNode(doc, []string{data.Key.ValueString()})
+		} else {
+			keyPath, err := ParseKeyPath(data.Key.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("key"),
+					"Invalid key",
+					err.Error(),
+				)
+				return
+			}
+			node = lookupKeyPath(doc.Content[0], keyPath)
+		}
+		if node == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("key"),
+				"Key not found",
+				fmt.Sprintf("The key %q does not exist in configuration %q in group %q.", data.Key.ValueString(), opts.DataID, opts.Group),
+			)
+			return
+		}
+		value, err := nodeToString(node)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to encode value",
+				err.Error(),
+			)
+			return
+		}
+		data.Value = value
+	}
+
+	data.ID = types.StringValue(BuildThreePartID(opts.NamespaceID, opts.Group, opts.DataID))
+	data.Type = types.StringValue(contentType)
+	data.Document = types.DynamicValue(document)
+	data.Md5 = types.StringValue(cfg.Md5)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ParseKeyPath parses a dotted key path like `a.b[0].c` or a JSONPath like
+// `$.a['b.c'][0]`.
+func ParseKeyPath(keyPath string) ([]KeyPathElement, error) {
+	var elements []KeyPathElement
+	s := keyPath
+	if strings.HasPrefix(s, "$") {
+		s = strings.TrimPrefix(s[1:], ".")
+	} else if s == "" {
+		return nil, fmt.Errorf("key path must not be empty")
+	}
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '.':
+			if i == 0 || i+1 == len(s) || s[i+1] == '.' || s[i+1] == '[' {
+				return nil, fmt.Errorf("invalid key path %q: empty key at offset %d", keyPath, i)
+			}
+			i++
+		case '[':
+			if i+1 < len(s) && (s[i+1] == '\'' || s[i+1] == '"') {
+				// Quoted keys end at the matching quote followed by ].
+				closing := strings.Index(s[i+2:], string(s[i+1])+"]")
+				if closing < 0 {
+					return nil, fmt.Errorf("invalid key path %q: unterminated quoted key at offset %d", keyPath, i)
+				}
+				elements = append(elements, KeyPathElement{Key: s[i+2 : i+2+closing]})
+				i += 2 + closing + 2
+				continue
+			}
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid key path %q: missing ] after offset %d", keyPath, i)
+			}
+			inner := s[i+1 : i+end]
+			index, err := strconv.Atoi(inner)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid key path %q: invalid index %q", keyPath, inner)
+			}
+			elements = append(elements, KeyPathElement{Index: index, IsIndex: true})
+			i += end + 1
+		default:
+			end := strings.IndexAny(s[i:], ".[")
+			if end < 0 {
+				end = len(s) - i
+			}
+			elements = append(elements, KeyPathElement{Key: s[i : i+end]})
+			i += end
+		}
+	}
+	return elements, nil
+}
+
+// nodeToString returns scalars as they are written and objects and arrays
+// JSON encoded.
+func nodeToString(node *yaml.Node) (types.String, error) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode {
+		if node.ShortTag() == "!!null" {
+			return types.StringNull(), nil
+		}
+		return types.StringValue(node.Value), nil
+	}
+	buf := new(bytes.Buffer)
+	if err := writeJSONNode(buf, node); err != nil {
+		return types.StringNull(), err
+	}
+	return types.StringValue(buf.String()), nil
+}
+
+// nodeToValue converts a node into a Terraform value, objects become object
+// values and arrays become tuple values.
+func nodeToValue(node *yaml.Node) (attr.Value, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return nodeToValue(node.Alias)
+	case yaml.MappingNode:
+		attrTypes := make(map[string]attr.Type)
+		attrs := make(map[string]attr.Value)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := nodeToValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[node.Content[i].Value] = value.Type(context.Background())
+			attrs[node.Content[i].Value] = value
+		}
+		value, diags := types.ObjectValue(attrTypes, attrs)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to convert object at line %d", node.Line)
+		}
+		return value, nil
+	case yaml.SequenceNode:
+		elemTypes := make([]attr.Type, 0, len(node.Content))
+		elems := make([]attr.Value, 0, len(node.Content))
+		for _, item := range node.Content {
+			value, err := nodeToValue(item)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes = append(elemTypes, value.Type(context.Background()))
+			elems = append(elems, value)
+		}
+		value, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to convert array at line %d", node.Line)
+		}
+		return value, nil
+	}
+	switch node.ShortTag() {
+	case "!!null":
+		// Nulls have no type, they are represented as null strings.
+		return types.StringNull(), nil
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return nil, err
+		}
+		return types.BoolValue(b), nil
+	case "!!int", "!!float":
+		if f, _, err := big.ParseFloat(node.Value, 0, 512, big.ToNearestEven); err == nil {
+			return types.NumberValue(f), nil
+		}
+		var v any
+		if err := node.Decode(&v); err != nil {
+			return nil, err
+		}
+		switch n := v.(type) {
+		case int:
+			return types.NumberValue(new(big.Float).SetInt64(int64(n))), nil
+		case int64:
+			return types.NumberValue(new(big.Float).SetInt64(n)), nil
+		case uint64:
+			return types.NumberValue(new(big.Float).SetUint64(n)), nil
+		}
+		// Infinity and NaN are not numbers in Terraform.
+		return types.StringValue(node.Value), nil
+	default:
+		return types.StringValue(node.Value), nil
+	}
+}
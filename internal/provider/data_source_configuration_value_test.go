@@ -0,0 +1,168 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"math/big"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
+)
+
+func TestParseKeyPath(t *testing.T) {
+	cases := map[string][]KeyPathElement{
+		"a":                  {{Key: "a"}},
+		"a.b[1].c":           {{Key: "a"}, {Key: "b"}, {Index: 1, IsIndex: true}, {Key: "c"}},
+		"$":                  nil,
+		"$.a['b.c'][0]":      {{Key: "a"}, {Key: "b.c"}, {Index: 0, IsIndex: true}},
+		`$["x]y"].z`:         {{Key: "x]y"}, {Key: "z"}},
+		"$[2]":               {{Index: 2, IsIndex: true}},
+		"spring.datasource":  {{Key: "spring"}, {Key: "datasource"}},
+		"servers[10].host.x": {{Key: "servers"}, {Index: 10, IsIndex: true}, {Key: "host"}, {Key: "x"}},
+	}
+	for keyPath, want := range cases {
+		got, err := ParseKeyPath(keyPath)
+		if err != nil {
+			t.Errorf("%s: %s", keyPath, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected %v, got %v", keyPath, want, got)
+		}
+	}
+	for _, keyPath := range []string{"", "a..b", ".a", "a.", "a[x]", "a[-1]", "a[0", "a['b]"} {
+		if _, err := ParseKeyPath(keyPath); err == nil {
+			t.Errorf("%s: expected error", keyPath)
+		}
+	}
+}
+
+func TestConfigurationValue(t *testing.T) {
+	doc, err := parseContentNode("db:\n  host: db.local\n  port: 5432\n  ratio: 0.5\n  replicas: [a, b]\n  tls: true\n  password: ~\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for keyPath, want := range map[string]types.String{
+		"db.host":          types.StringValue("db.local"),
+		"db.port":          types.StringValue("5432"),
+		"db.replicas":      types.StringValue(`["a","b"]`),
+		"$.db.replicas[1]": types.StringValue("b"),
+		"db.password":      types.StringNull(),
+	} {
+		elements, err := ParseKeyPath(keyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		node := lookupKeyPath(doc.Content[0], elements)
+		if node == nil {
+			t.Errorf("%s: not found", keyPath)
+			continue
+		}
+		got, err := nodeToString(node)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("%s: expected %s, got %s", keyPath, want, got)
+		}
+	}
+	if node := lookupKeyPath(doc.Content[0], []KeyPathElement{{Key: "db"}, {Index: 0, IsIndex: true}}); node != nil {
+		t.Errorf("expected index of an object not to be found")
+	}
+
+	value, err := nodeToValue(doc.Content[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := value.(types.Object).Attributes()["db"].(types.Object).Attributes()
+	if got := db["port"].(types.Number).ValueBigFloat(); got.Cmp(big.NewFloat(5432)) != 0 {
+		t.Errorf("expected port 5432, got %s", got)
+	}
+	if got := db["ratio"].(types.Number).ValueBigFloat(); got.Cmp(big.NewFloat(0.5)) != 0 {
+		t.Errorf("expected ratio 0.5, got %s", got)
+	}
+	if !db["tls"].Equal(types.BoolValue(true)) {
+		t.Errorf("expected tls true, got %s", db["tls"])
+	}
+	if !db["password"].IsNull() {
+		t.Errorf("expected null password, got %s", db["password"])
+	}
+	if got := len(db["replicas"].(types.Tuple).Elements()); got != 2 {
+		t.Errorf("expected 2 replicas, got %d", got)
+	}
+
+	doc = parsePropertiesNode("# comment\ndb.host=old\ndb.host = db.local\nurl=jdbc:mysql://x\n")
+	value, err = nodeToValue(doc.Content[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := types.ObjectValueMust(
+		map[string]attr.Type{"db.host": types.StringType, "url": types.StringType},
+		map[string]attr.Value{"db.host": types.StringValue("db.local"), "url": types.StringValue("jdbc:mysql://x")},
+	)
+	if !value.Equal(want) {
+		t.Errorf("expected %s, got %s", want, value)
+	}
+}
+
+func TestAccConfigurationValueDataSource(t *testing.T) {
+	resourceName := "data.nacos_configuration_value.test"
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{
+		DataID:  "value-test.yaml",
+		Group:   "DEFAULT_GROUP",
+		Content: "db:\n  host: db.local\n  port: 5432\n",
+		Type:    "yaml",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "nacos_configuration_value" "test" {
+  data_id = "value-test.yaml"
+  group   = "DEFAULT_GROUP"
+  key     = "db.host"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("value"),
+						knownvalue.StringExact("db.local"),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("type"),
+						knownvalue.StringExact("yaml"),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("document").AtMapKey("db").AtMapKey("port"),
+						knownvalue.Int64Exact(5432),
+					),
+				},
+			},
+			{
+				Config: `
+data "nacos_configuration_value" "test" {
+  data_id = "value-test.yaml"
+  group   = "DEFAULT_GROUP"
+  key     = "db.user"
+}
+`,
+				ExpectError: regexp.MustCompile("Key not found"),
+			},
+		},
+	})
+}
@@ -3,13 +3,28 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/joelee2012/go-nacos"
 )
 
+// Search modes of the configurations data source.
+const (
+	SearchModeExact = "exact"
+	SearchModeBlur  = "blur"
+)
+
+// searchPageSize is the default number of configurations requested per page.
+const searchPageSize = 100
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &ConfigurationsDataSource{}
 
@@ -20,14 +35,32 @@ func NewConfigurationsDataSource() datasource.DataSource {
 // ConfigurationsDataSource defines the data source implementation.
 type ConfigurationsDataSource struct {
 	client *nacos.Client
+	api    *NacosAPI
+	cipher *ConfigurationCipher
 }
 
 // ConfigurationsDataSourceModel describes the data source data model.
 type ConfigurationsDataSourceModel struct {
-	NamespaceID types.String          `tfsdk:"namespace_id"`
-	DataID      types.String          `tfsdk:"data_id"`
-	Group       types.String          `tfsdk:"group"`
-	Items       []*ConfigurationModel `tfsdk:"items"`
+	NamespaceID     types.String          `tfsdk:"namespace_id"`
+	DataID          types.String          `tfsdk:"data_id"`
+	Group           types.String          `tfsdk:"group"`
+	SearchMode      types.String          `tfsdk:"search_mode"`
+	Tags            types.Set             `tfsdk:"tags"`
+	Application     types.String          `tfsdk:"application"`
+	Type            types.String          `tfsdk:"type"`
+	ContentContains types.String          `tfsdk:"content_contains"`
+	Limit           types.Int64           `tfsdk:"limit"`
+	PageSize        types.Int64           `tfsdk:"page_size"`
+	Sensitive       types.Bool            `tfsdk:"sensitive"`
+	TotalCount      types.Int64           `tfsdk:"total_count"`
+	Items           []*ConfigurationModel `tfsdk:"items"`
+}
+
+// Searching reports whether any of the search attributes is set, otherwise
+// configurations are listed by namespace, group and data id only.
+func (c *ConfigurationsDataSourceModel) Searching() bool {
+	return !c.SearchMode.IsNull() || !c.Tags.IsNull() || !c.Application.IsNull() || !c.Type.IsNull() ||
+		!c.ContentContains.IsNull() || !c.Limit.IsNull() || !c.PageSize.IsNull()
 }
 
 type ConfigurationModel struct {
@@ -43,6 +76,7 @@ type ConfigurationModel struct {
 	CreateTime       types.Int64  `tfsdk:"create_time"`
 	ModifyTime       types.Int64  `tfsdk:"modify_time"`
 	Description      types.String `tfsdk:"description"`
+	SensitiveContent types.String `tfsdk:"sensitive_content"`
 	// Tags             types.Set    `tfsdk:"tags"`
 }
 
@@ -57,14 +91,66 @@ func (d *ConfigurationsDataSource) Schema(ctx context.Context, req datasource.Sc
 
 		Attributes: map[string]schema.Attribute{
 			"data_id": schema.StringAttribute{
-				Optional: true,
+				MarkdownDescription: "Configuration data id, with `search_mode` `blur` it may contain `*` wildcards, e.g. `*-gateway.yaml`.",
+				Optional:            true,
 			},
 			"group": schema.StringAttribute{
-				Optional: true,
+				MarkdownDescription: "Configuration group, with `search_mode` `blur` it may contain `*` wildcards.",
+				Optional:            true,
 			},
 			"namespace_id": schema.StringAttribute{
 				Optional: true,
 			},
+			"search_mode": schema.StringAttribute{
+				MarkdownDescription: "How `data_id` and `group` are matched, `exact` (default) or `blur` for fuzzy matching. " +
+					"Setting any of `search_mode`, `tags`, `application`, `type`, `content_contains`, `limit` or `page_size` searches the configurations of `namespace_id` only.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(SearchModeExact, SearchModeBlur),
+				},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Only return configurations with these tags.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"application": schema.StringAttribute{
+				MarkdownDescription: "Only return configurations of this application.",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only return configurations of this type.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"text", "json", "xml", "yaml", "html", "properties"}...),
+				},
+			},
+			"content_contains": schema.StringAttribute{
+				MarkdownDescription: "Only return configurations whose content contains this text.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of configurations to return, default is all matching configurations.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of configurations requested per page while searching, default is `100`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 500),
+				},
+			},
+			"total_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of matching configurations, which may exceed the number of `items` when `limit` is set.",
+				Computed:            true,
+			},
+			"sensitive": schema.BoolAttribute{
+				MarkdownDescription: "Return the configuration content in `sensitive_content` instead of `content` of each item.",
+				Optional:            true,
+			},
 			"items": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -86,9 +172,14 @@ func (d *ConfigurationsDataSource) Schema(ctx context.Context, req datasource.Sc
 							Computed:            true,
 						},
 						"content": schema.StringAttribute{
-							MarkdownDescription: "Configuration content.",
+							MarkdownDescription: "Configuration content, empty when `sensitive` is `true` or the content cannot be decrypted.",
 							Computed:            true,
 						},
+						"sensitive_content": schema.StringAttribute{
+							MarkdownDescription: "Configuration content when `sensitive` is `true`.",
+							Computed:            true,
+							Sensitive:           true,
+						},
 						"type": schema.StringAttribute{
 							MarkdownDescription: "Configuration type.",
 							Computed:            true,
@@ -130,18 +221,20 @@ func (d *ConfigurationsDataSource) Configure(ctx context.Context, req datasource
 		return
 	}
 
-	client, ok := req.ProviderData.(*nacos.Client)
+	providerData, ok := req.ProviderData.(*NacosProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *nacos.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
+	d.api = providerData.API
+	d.cipher = providerData.Cipher
 }
 
 func (d *ConfigurationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -152,28 +245,53 @@ func (d *ConfigurationsDataSource) Read(ctx context.Context, req datasource.Read
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	allCs := new(nacos.ConfigurationList)
-	var err error
-	if data.DataID.IsNull() && data.Group.IsNull() && data.NamespaceID.IsNull() {
-		allCs, err = d.client.ListAllConfig(ctx)
-	} else if data.DataID.IsNull() {
-		allCs, err = d.client.ListConfigInNs(ctx, data.NamespaceID.ValueString(), data.Group.ValueString())
+	var configs []*nacos.Configuration
+	if data.Searching() {
+		var diags diag.Diagnostics
+		configs, diags = d.search(ctx, &data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	} else {
-		allCs, err = d.client.ListConfig(ctx, &nacos.ListCfgOpts{DataID: data.DataID.ValueString(), Group: data.Group.ValueString(), NamespaceID: data.NamespaceID.ValueString()})
-	}
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read configurations",
-			err.Error(),
-		)
-		return
+		allCs := new(nacos.ConfigurationList)
+		var err error
+		if data.DataID.IsNull() && data.Group.IsNull() && data.NamespaceID.IsNull() {
+			allCs, err = d.client.ListAllConfig(ctx)
+		} else if data.DataID.IsNull() {
+			allCs, err = d.client.ListConfigInNs(ctx, data.NamespaceID.ValueString(), data.Group.ValueString())
+		} else {
+			allCs, err = d.client.ListConfig(ctx, &nacos.ListCfgOpts{DataID: data.DataID.ValueString(), Group: data.Group.ValueString(), NamespaceID: data.NamespaceID.ValueString()})
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read configurations",
+				err.Error(),
+			)
+			return
+		}
+		configs = allCs.Items
+		data.TotalCount = types.Int64Value(int64(len(configs)))
 	}
-	for _, cfg := range allCs.Items {
-		data.Items = append(data.Items, &ConfigurationModel{
-			ID:               types.StringValue(BuildThreePartID(cfg.NamespaceID, cfg.Group, cfg.DataID)),
+	var notDecrypted []string
+	for _, cfg := range configs {
+		id := BuildThreePartID(cfg.NamespaceID, cfg.Group, cfg.DataID)
+		// Without an encryption key the content is returned as stored on the server.
+		content := types.StringValue(cfg.Content)
+		if d.cipher != nil {
+			plain, err := d.cipher.DecryptContent(ctx, cfg.DataID, cfg.Content, cfg.EncryptedDataKey)
+			if err != nil {
+				notDecrypted = append(notDecrypted, fmt.Sprintf("%s: %s", id, err.Error()))
+				content = types.StringNull()
+			} else {
+				content = types.StringValue(plain)
+			}
+		}
+		item := &ConfigurationModel{
+			ID:               types.StringValue(id),
 			DataID:           types.StringValue(cfg.DataID),
 			Group:            types.StringValue(cfg.GetGroup()),
-			Content:          types.StringValue(cfg.Content),
+			Content:          content,
 			NamespaceID:      types.StringValue(cfg.GetNamespace()),
 			Type:             types.StringValue(cfg.Type),
 			Md5:              types.StringValue(cfg.Md5),
@@ -182,10 +300,76 @@ func (d *ConfigurationsDataSource) Read(ctx context.Context, req datasource.Read
 			CreateTime:       types.Int64Value(cfg.CreateTime),
 			ModifyTime:       types.Int64Value(cfg.ModifyTime),
 			Description:      types.StringValue(cfg.Description),
+			SensitiveContent: types.StringNull(),
 			// Tags:             types.StringValue(config.Tags),
-		})
+		}
+		if data.Sensitive.ValueBool() {
+			item.SensitiveContent = item.Content
+			item.Content = types.StringNull()
+		}
+		data.Items = append(data.Items, item)
+	}
+	if len(notDecrypted) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Configurations not decrypted",
+			fmt.Sprintf("Their content is null.\n\n%s", strings.Join(notDecrypted, "\n")),
+		)
 	}
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// search pages through the configurations matching the search attributes
+// until limit configurations are found.
+func (d *ConfigurationsDataSource) search(ctx context.Context, data *ConfigurationsDataSourceModel) ([]*nacos.Configuration, diag.Diagnostics) {
+	tags, diags := JoinTags(ctx, data.Tags)
+	if diags.HasError() {
+		return nil, diags
+	}
+	opts := &SearchCfgOpts{
+		Search:       "accurate",
+		DataID:       data.DataID.ValueString(),
+		Group:        data.Group.ValueString(),
+		NamespaceID:  data.NamespaceID.ValueString(),
+		AppName:      data.Application.ValueString(),
+		Tags:         tags,
+		Type:         data.Type.ValueString(),
+		ConfigDetail: data.ContentContains.ValueString(),
+		PageSize:     searchPageSize,
+	}
+	if data.SearchMode.ValueString() == SearchModeBlur {
+		opts.Search = "blur"
+	}
+	if !data.PageSize.IsNull() {
+		opts.PageSize = int(data.PageSize.ValueInt64())
+	}
+	var configs []*nacos.Configuration
+	for opts.PageNo = 1; ; opts.PageNo++ {
+		tflog.Debug(ctx, "searching configurations", map[string]any{
+			"namespace_id": opts.NamespaceID,
+			"group":        opts.Group,
+			"data_id":      opts.DataID,
+			"search":       opts.Search,
+			"page_no":      opts.PageNo,
+		})
+		page, err := d.api.SearchConfig(ctx, opts)
+		if err != nil {
+			diags.AddError(
+				"Unable to search configurations",
+				err.Error(),
+			)
+			return nil, diags
+		}
+		data.TotalCount = types.Int64Value(int64(page.TotalCount))
+		configs = append(configs, page.Items...)
+		if !data.Limit.IsNull() && int64(len(configs)) >= data.Limit.ValueInt64() {
+			configs = configs[:data.Limit.ValueInt64()]
+			break
+		}
+		if len(page.Items) == 0 || opts.PageNo >= page.PagesAvailable {
+			break
+		}
+	}
+	return configs, diags
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -58,3 +59,99 @@ data "nacos_configurations" "test" {
 		},
 	})
 }
+
+func TestAccConfigurationsDataSource_search(t *testing.T) {
+	resourceName := "data.nacos_configurations.test"
+	for _, dataId := range []string{"payments-gateway.yaml", "orders-gateway.yaml"} {
+		setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: dataId, Group: "search-group", Content: "routes: []", Type: "yaml", Tags: "payments"})
+	}
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: "payments-api.yaml", Group: "search-group", Content: "port: 80", Type: "yaml", Tags: "payments"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "nacos_configurations" "test" {
+  group       = "search-group"
+  search_mode = "blur"
+  data_id     = "*-gateway.yaml"
+  tags        = ["payments"]
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("total_count"),
+						knownvalue.Int64Exact(2),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("items"),
+						knownvalue.ListSizeExact(2),
+					),
+				},
+			},
+			{
+				Config: `
+data "nacos_configurations" "test" {
+  group            = "search-group"
+  search_mode      = "blur"
+  content_contains = "routes"
+  limit            = 1
+  page_size        = 1
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("total_count"),
+						knownvalue.Int64Exact(2),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("items"),
+						knownvalue.ListSizeExact(1),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccConfigurationsDataSource_undecryptable(t *testing.T) {
+	if os.Getenv("NACOS_ENCRYPTION_KEY") == "" {
+		t.Skip("configurations are only decrypted with an encryption key")
+	}
+	resourceName := "data.nacos_configurations.test"
+	// The configuration was not encrypted by the provider, it has no data key.
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: "cipher-aes-undecryptable.yaml", Group: "undecryptable-group", Content: "a: 1", Type: "yaml"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "nacos_configurations" "test" {
+  data_id = "cipher-aes-undecryptable.yaml"
+  group   = "undecryptable-group"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("items").AtSliceIndex(0).AtMapKey("content"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("items").AtSliceIndex(0).AtMapKey("data_id"),
+						knownvalue.StringExact("cipher-aes-undecryptable.yaml"),
+					),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ListenedConfigurationsDataSource{}
+
+func NewListenedConfigurationsDataSource() datasource.DataSource {
+	return &ListenedConfigurationsDataSource{}
+}
+
+// ListenedConfigurationsDataSource defines the data source implementation.
+type ListenedConfigurationsDataSource struct {
+	api *NacosAPI
+}
+
+// ListenedConfigurationsDataSourceModel describes the data source data model.
+type ListenedConfigurationsDataSourceModel struct {
+	ID             types.String                  `tfsdk:"id"`
+	IP             types.String                  `tfsdk:"ip"`
+	NamespaceID    types.String                  `tfsdk:"namespace_id"`
+	Configurations []*ListenedConfigurationModel `tfsdk:"configurations"`
+}
+
+type ListenedConfigurationModel struct {
+	ID          types.String `tfsdk:"id"`
+	DataID      types.String `tfsdk:"data_id"`
+	Group       types.String `tfsdk:"group"`
+	NamespaceID types.String `tfsdk:"namespace_id"`
+	Md5         types.String `tfsdk:"md5"`
+}
+
+func (d *ListenedConfigurationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_listened_configurations"
+}
+
+func (d *ListenedConfigurationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Configurations a client listens to, and the md5 of the content it currently holds for each one. " +
+			"Use `nacos_configuration_listeners` to look up the clients listening to a configuration.",
+
+		Attributes: map[string]schema.Attribute{
+			"ip": schema.StringAttribute{
+				MarkdownDescription: "Client ip.",
+				Required:            true,
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Only return configurations of this namespace.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource, the client ip.",
+				Computed:            true,
+			},
+			"configurations": schema.ListNestedAttribute{
+				MarkdownDescription: "Listened configurations, ordered by id.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Configuration id, in the format of `<namespace_id>:<group>:<data_id>`.",
+							Computed:            true,
+						},
+						"data_id": schema.StringAttribute{
+							MarkdownDescription: "Configuration data id.",
+							Computed:            true,
+						},
+						"group": schema.StringAttribute{
+							MarkdownDescription: "Configuration group.",
+							Computed:            true,
+						},
+						"namespace_id": schema.StringAttribute{
+							MarkdownDescription: "Configuration namespace id.",
+							Computed:            true,
+						},
+						"md5": schema.StringAttribute{
+							MarkdownDescription: "Md5 of the configuration content the client holds.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ListenedConfigurationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.api = providerData.API
+}
+
+func (d *ListenedConfigurationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ListenedConfigurationsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ip := data.IP.ValueString()
+	tflog.Debug(ctx, "read listened configurations", map[string]any{"ip": ip})
+	listeners, err := d.api.ListClientListeners(ctx, ip)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read listened configurations",
+			err.Error(),
+		)
+		return
+	}
+	data.ID = types.StringValue(ip)
+	data.Configurations = make([]*ListenedConfigurationModel, 0, len(listeners))
+	namespaceId := data.NamespaceID.ValueString()
+	for _, listener := range listeners {
+		if !data.NamespaceID.IsNull() && namespaceId != listener.NamespaceID && !(isPublicNamespace(namespaceId) && isPublicNamespace(listener.NamespaceID)) {
+			continue
+		}
+		data.Configurations = append(data.Configurations, &ListenedConfigurationModel{
+			ID:          types.StringValue(BuildThreePartID(listener.NamespaceID, listener.Group, listener.DataID)),
+			DataID:      types.StringValue(listener.DataID),
+			Group:       types.StringValue(listener.Group),
+			NamespaceID: types.StringValue(listener.NamespaceID),
+			Md5:         types.StringValue(listener.Md5),
+		})
+	}
+	sort.Slice(data.Configurations, func(i, j int) bool {
+		return data.Configurations[i].ID.ValueString() < data.Configurations[j].ID.ValueString()
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
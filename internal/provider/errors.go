@@ -9,12 +9,16 @@ import (
 	"github.com/joelee2012/go-nacos"
 )
 
-// IsNotFoundError checks whether an error from the go-nacos client indicates
-// that the requested resource does not exist.
+// IsNotFoundError checks whether an error from the go-nacos client or the
+// Nacos HTTP API indicates that the requested resource does not exist.
 func IsNotFoundError(err error) bool {
 	var nacosErr nacos.NacosErr
 	if errors.As(err, &nacosErr) {
 		return nacosErr.IsNotFound()
 	}
+	var apiErr *NacosAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IsNotFound()
+	}
 	return errors.Is(err, nacos.ErrNotFound)
 }
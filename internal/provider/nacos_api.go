@@ -0,0 +1,741 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joelee2012/go-nacos"
+)
+
+// nacosResourceNotFound is the error code of Nacos 3 responses for missing
+// resources.
+const nacosResourceNotFound = 20004
+
+// NacosAPI calls the Nacos HTTP API for the operations the go-nacos client
+// does not support. It uses the console API of Nacos 3 (api_version v3) and
+// the open API of Nacos 2 (api_version v1), the host is the one of the
+// go-nacos client.
+type NacosAPI struct {
+	host       string
+	username   string
+	password   string
+	apiVersion string
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func NewNacosAPI(host, username, password, apiVersion string) *NacosAPI {
+	return &NacosAPI{
+		host:       strings.TrimRight(host, "/"),
+		username:   username,
+		password:   password,
+		apiVersion: apiVersion,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// NacosAPIError is an unsuccessful response of the Nacos HTTP API.
+type NacosAPIError struct {
+	StatusCode int
+	// Code is the error code of the response body, zero when there is none.
+	Code    int
+	Message string
+}
+
+func (e *NacosAPIError) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("nacos returned status %d, code %d: %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("nacos returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether the requested resource does not exist.
+func (e *NacosAPIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound || e.Code == nacosResourceNotFound
+}
+
+// isV3 reports whether the server is Nacos 3.x.
+func (a *NacosAPI) isV3() bool {
+	return a.apiVersion == "v3"
+}
+
+// login returns a cached access token, or logs in when it expired.
+func (a *NacosAPI) login(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.accessToken != "" && time.Now().Before(a.expiresAt) {
+		return a.accessToken, nil
+	}
+	loginPath := "/v1/auth/login"
+	if a.isV3() {
+		loginPath = "/v3/auth/user/login"
+	}
+	form := url.Values{"username": {a.username}, "password": {a.password}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.host+loginPath, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	body, _, err := a.send(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to log in: %w", err)
+	}
+	var token struct {
+		AccessToken string `json:"accessToken"`
+		TokenTTL    int64  `json:"tokenTtl"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("unable to decode login response: %w", err)
+	}
+	a.accessToken = token.AccessToken
+	// Renew the token before it expires.
+	a.expiresAt = time.Now().Add(time.Duration(token.TokenTTL) * time.Second * 9 / 10)
+	return a.accessToken, nil
+}
+
+// call sends a request with the access token and returns the response body
+// and headers. Parameters are sent in the form body of POST requests and in
+// the query string otherwise.
+func (a *NacosAPI) call(ctx context.Context, method, path string, params url.Values, header http.Header) ([]byte, http.Header, error) {
+	token, err := a.login(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	query := url.Values{}
+	if token != "" {
+		query.Set("accessToken", token)
+	}
+	var body io.Reader
+	if method == http.MethodPost {
+		body = strings.NewReader(params.Encode())
+	} else {
+		for k, v := range params {
+			query[k] = v
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, a.host+path+"?"+query.Encode(), body)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	return a.send(req)
+}
+
+func (a *NacosAPI) send(req *http.Request) ([]byte, http.Header, error) {
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &NacosAPIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
+		var result struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &result) == nil && result.Message != "" {
+			apiErr.Code, apiErr.Message = result.Code, result.Message
+		}
+		return nil, nil, apiErr
+	}
+	return body, resp.Header, nil
+}
+
+// decodeResult decodes the data of a Nacos 3 result, or of a Nacos 2 rest
+// result when ok is 200.
+func decodeResult(body []byte, ok int, v any) error {
+	var result struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("unable to decode response: %w", err)
+	}
+	if result.Code != ok {
+		return &NacosAPIError{StatusCode: http.StatusOK, Code: result.Code, Message: result.Message}
+	}
+	if len(result.Data) == 0 || string(result.Data) == "null" {
+		return &NacosAPIError{StatusCode: http.StatusNotFound, Message: "resource not found"}
+	}
+	if v == nil {
+		return nil
+	}
+	if err := json.Unmarshal(result.Data, v); err != nil {
+		return fmt.Errorf("unable to decode response data: %w", err)
+	}
+	return nil
+}
+
+// PublishCfgOpts are the options to publish a configuration.
+type PublishCfgOpts struct {
+	DataID, Group, NamespaceID, Content, Type, Application, Description, Tags string
+	// EncryptedDataKey is the data key of content encrypted by the provider.
+	EncryptedDataKey string
+	// CasMd5 makes the publish fail when the md5 of the configuration on
+	// the server differs.
+	CasMd5 string
+	// BetaIps publishes a beta release to the comma separated client IPs.
+	BetaIps string
+	// Tag publishes the variant of the configuration for the tag.
+	Tag string
+}
+
+// configParams returns the parameters identifying a configuration.
+func (a *NacosAPI) configParams(dataID, group, namespaceID string) url.Values {
+	if a.isV3() {
+		return url.Values{"dataId": {dataID}, "groupName": {group}, "namespaceId": {namespaceID}}
+	}
+	return url.Values{"dataId": {dataID}, "group": {group}, "tenant": {namespaceID}}
+}
+
+func (a *NacosAPI) configPath() string {
+	if a.isV3() {
+		return "/v3/console/cs/config"
+	}
+	return "/v1/cs/configs"
+}
+
+// PublishConfig publishes a configuration.
+func (a *NacosAPI) PublishConfig(ctx context.Context, opts *PublishCfgOpts) error {
+	params := a.configParams(opts.DataID, opts.Group, opts.NamespaceID)
+	params.Set("content", opts.Content)
+	params.Set("type", opts.Type)
+	params.Set("appName", opts.Application)
+	params.Set("desc", opts.Description)
+	if a.isV3() {
+		params.Set("configTags", opts.Tags)
+	} else {
+		params.Set("config_tags", opts.Tags)
+	}
+	if opts.EncryptedDataKey != "" {
+		params.Set("encryptedDataKey", opts.EncryptedDataKey)
+	}
+	if opts.Tag != "" {
+		params.Set("tag", opts.Tag)
+	}
+	header := http.Header{}
+	if opts.BetaIps != "" {
+		header.Set("betaIps", opts.BetaIps)
+	}
+	if opts.CasMd5 != "" {
+		header.Set("casMd5", opts.CasMd5)
+	}
+	body, _, err := a.call(ctx, http.MethodPost, a.configPath(), params, header)
+	if err != nil {
+		return err
+	}
+	return a.decodeSuccess(body, "configuration was not published")
+}
+
+// decodeSuccess checks the boolean response of a configuration operation,
+// a Nacos 3 result or the plain body of Nacos 2.
+func (a *NacosAPI) decodeSuccess(body []byte, failure string) error {
+	if a.isV3() {
+		var success bool
+		if err := decodeResult(body, 0, &success); err != nil {
+			return err
+		}
+		if !success {
+			return &NacosAPIError{StatusCode: http.StatusOK, Message: failure}
+		}
+		return nil
+	}
+	if strings.TrimSpace(string(body)) != "true" {
+		return &NacosAPIError{StatusCode: http.StatusOK, Message: strings.TrimSpace(string(body))}
+	}
+	return nil
+}
+
+// UpdateUserPassword sets the password of a user.
+func (a *NacosAPI) UpdateUserPassword(ctx context.Context, username, password string) error {
+	path := "/v1/auth/users"
+	if a.isV3() {
+		path = "/v3/auth/user"
+	}
+	params := url.Values{"username": {username}, "newPassword": {password}}
+	body, _, err := a.call(ctx, http.MethodPut, path, params, nil)
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("unable to decode response: %w", err)
+	}
+	if result.Code != a.resultCode() {
+		return &NacosAPIError{StatusCode: http.StatusOK, Code: result.Code, Message: result.Message}
+	}
+	return nil
+}
+
+// Page is a page of the items listed by the Nacos HTTP API.
+type Page[T any] struct {
+	TotalCount     int  `json:"totalCount"`
+	PageNumber     int  `json:"pageNumber"`
+	PagesAvailable int  `json:"pagesAvailable"`
+	Items          []*T `json:"pageItems"`
+}
+
+// nacosInt64 decodes the ids and times of the Nacos HTTP API, which are
+// numbers or strings depending on the server version. Times given as dates
+// are decoded to milliseconds since the epoch.
+type nacosInt64 int64
+
+func (n *nacosInt64) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		var i int64
+		if err := json.Unmarshal(b, &i); err != nil {
+			return err
+		}
+		*n = nacosInt64(i)
+		return nil
+	}
+	if s == "" {
+		*n = 0
+		return nil
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*n = nacosInt64(i)
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.000-0700", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			*n = nacosInt64(t.UnixMilli())
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid number or time %q", s)
+}
+
+// ConfigurationHistory is a revision of a configuration.
+type ConfigurationHistory struct {
+	ID                                                                 int64
+	DataID, Group, NamespaceID, Content, Md5, EncryptedDataKey, OpType string
+	SrcUser, SrcIP, AppName                                            string
+	CreateTime, ModifyTime                                             int64
+}
+
+func (h *ConfigurationHistory) UnmarshalJSON(b []byte) error {
+	// Nacos 2 and Nacos 3 name some of the fields differently.
+	var v struct {
+		ID               nacosInt64 `json:"id"`
+		DataID           string     `json:"dataId"`
+		Group            string     `json:"group"`
+		GroupName        string     `json:"groupName"`
+		Tenant           string     `json:"tenant"`
+		NamespaceID      string     `json:"namespaceId"`
+		Content          string     `json:"content"`
+		Md5              string     `json:"md5"`
+		EncryptedDataKey string     `json:"encryptedDataKey"`
+		OpType           string     `json:"opType"`
+		SrcUser          string     `json:"srcUser"`
+		SrcIP            string     `json:"srcIp"`
+		AppName          string     `json:"appName"`
+		CreatedTime      nacosInt64 `json:"createdTime"`
+		LastModifiedTime nacosInt64 `json:"lastModifiedTime"`
+		CreateTime       nacosInt64 `json:"createTime"`
+		ModifyTime       nacosInt64 `json:"modifyTime"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*h = ConfigurationHistory{
+		ID:               int64(v.ID),
+		DataID:           v.DataID,
+		Group:            v.Group + v.GroupName,
+		NamespaceID:      v.Tenant + v.NamespaceID,
+		Content:          v.Content,
+		Md5:              v.Md5,
+		EncryptedDataKey: v.EncryptedDataKey,
+		// The operation type is stored in a fixed length column.
+		OpType:     strings.TrimSpace(v.OpType),
+		SrcUser:    v.SrcUser,
+		SrcIP:      v.SrcIP,
+		AppName:    v.AppName,
+		CreateTime: int64(v.CreatedTime + v.CreateTime),
+		ModifyTime: int64(v.LastModifiedTime + v.ModifyTime),
+	}
+	return nil
+}
+
+// ListHistoryOpts are the options to list the revisions of a configuration.
+type ListHistoryOpts struct {
+	DataID, Group, NamespaceID string
+	PageNo, PageSize           int
+}
+
+// ListHistory lists the revisions of a configuration, newest first, without
+// their content.
+func (a *NacosAPI) ListHistory(ctx context.Context, opts *ListHistoryOpts) (*Page[ConfigurationHistory], error) {
+	params := a.configParams(opts.DataID, opts.Group, opts.NamespaceID)
+	params.Set("pageNo", strconv.Itoa(opts.PageNo))
+	params.Set("pageSize", strconv.Itoa(opts.PageSize))
+	page := &Page[ConfigurationHistory]{}
+	if a.isV3() {
+		body, _, err := a.call(ctx, http.MethodGet, "/v3/console/cs/history/list", params, nil)
+		if err != nil {
+			return nil, err
+		}
+		return page, decodeResult(body, 0, page)
+	}
+	params.Set("search", "accurate")
+	body, _, err := a.call(ctx, http.MethodGet, "/v1/cs/history", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, page); err != nil {
+		return nil, fmt.Errorf("unable to decode response: %w", err)
+	}
+	return page, nil
+}
+
+// GetHistoryOpts are the options to get a revision of a configuration.
+type GetHistoryOpts struct {
+	ID                         int64
+	DataID, Group, NamespaceID string
+}
+
+// GetHistory gets a revision of a configuration with its content.
+func (a *NacosAPI) GetHistory(ctx context.Context, opts *GetHistoryOpts) (*ConfigurationHistory, error) {
+	params := a.configParams(opts.DataID, opts.Group, opts.NamespaceID)
+	params.Set("nid", strconv.FormatInt(opts.ID, 10))
+	path := "/v1/cs/history"
+	if a.isV3() {
+		path = "/v3/console/cs/history"
+	}
+	body, _, err := a.call(ctx, http.MethodGet, path, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	history := &ConfigurationHistory{}
+	if a.isV3() {
+		return history, decodeResult(body, 0, history)
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil, &NacosAPIError{StatusCode: http.StatusNotFound, Message: "revision not found"}
+	}
+	if err := json.Unmarshal(body, history); err != nil {
+		return nil, fmt.Errorf("unable to decode response: %w", err)
+	}
+	return history, nil
+}
+
+// apiConfiguration decodes a configuration of the Nacos HTTP API, Nacos 2
+// and Nacos 3 name some of the fields differently.
+type apiConfiguration struct {
+	DataID           string     `json:"dataId"`
+	Group            string     `json:"group"`
+	GroupName        string     `json:"groupName"`
+	Tenant           string     `json:"tenant"`
+	NamespaceID      string     `json:"namespaceId"`
+	Content          string     `json:"content"`
+	Type             string     `json:"type"`
+	Md5              string     `json:"md5"`
+	EncryptedDataKey string     `json:"encryptedDataKey"`
+	AppName          string     `json:"appName"`
+	Desc             string     `json:"desc"`
+	ConfigTags       string     `json:"configTags"`
+	CreateTime       nacosInt64 `json:"createTime"`
+	ModifyTime       nacosInt64 `json:"modifyTime"`
+}
+
+func (c *apiConfiguration) configuration() nacos.Configuration {
+	return nacos.Configuration{
+		DataID:           c.DataID,
+		Group:            c.Group + c.GroupName,
+		NamespaceID:      c.Tenant + c.NamespaceID,
+		Content:          c.Content,
+		Type:             c.Type,
+		Md5:              c.Md5,
+		EncryptedDataKey: c.EncryptedDataKey,
+		Application:      c.AppName,
+		Description:      c.Desc,
+		Tags:             c.ConfigTags,
+		CreateTime:       int64(c.CreateTime),
+		ModifyTime:       int64(c.ModifyTime),
+	}
+}
+
+// BetaConfiguration is the beta release of a configuration.
+type BetaConfiguration struct {
+	nacos.Configuration
+	// BetaIps are the comma separated IPs of the clients of the beta.
+	BetaIps string
+}
+
+// betaPath returns the path and the parameters of the beta release of a
+// configuration.
+func (a *NacosAPI) betaPath(dataID, group, namespaceID string) (string, url.Values) {
+	params := a.configParams(dataID, group, namespaceID)
+	if a.isV3() {
+		return "/v3/console/cs/config/beta", params
+	}
+	params.Set("beta", "true")
+	return "/v1/cs/configs", params
+}
+
+// resultCode returns the code of successful results of the Nacos HTTP API.
+func (a *NacosAPI) resultCode() int {
+	if a.isV3() {
+		return 0
+	}
+	return http.StatusOK
+}
+
+// GetBetaConfig gets the beta release of a configuration.
+func (a *NacosAPI) GetBetaConfig(ctx context.Context, opts *nacos.GetCfgOpts) (*BetaConfiguration, error) {
+	path, params := a.betaPath(opts.DataID, opts.Group, opts.NamespaceID)
+	body, _, err := a.call(ctx, http.MethodGet, path, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	var beta struct {
+		apiConfiguration
+		BetaIps string `json:"betaIps"`
+	}
+	if err := decodeResult(body, a.resultCode(), &beta); err != nil {
+		return nil, err
+	}
+	return &BetaConfiguration{Configuration: beta.configuration(), BetaIps: beta.BetaIps}, nil
+}
+
+// DeleteBetaConfig stops the beta release of a configuration.
+func (a *NacosAPI) DeleteBetaConfig(ctx context.Context, opts *nacos.DeleteCfgOpts) error {
+	path, params := a.betaPath(opts.DataID, opts.Group, opts.NamespaceID)
+	body, _, err := a.call(ctx, http.MethodDelete, path, params, nil)
+	if err != nil {
+		return err
+	}
+	return decodeResult(body, a.resultCode(), nil)
+}
+
+// TagCfgOpts identify the variant of a configuration for a tag.
+type TagCfgOpts struct {
+	DataID, Group, NamespaceID, Tag string
+}
+
+// DeleteTagConfig deletes the variant of a configuration for a tag.
+func (a *NacosAPI) DeleteTagConfig(ctx context.Context, opts *TagCfgOpts) error {
+	params := a.configParams(opts.DataID, opts.Group, opts.NamespaceID)
+	params.Set("tag", opts.Tag)
+	body, _, err := a.call(ctx, http.MethodDelete, a.configPath(), params, nil)
+	if err != nil {
+		return err
+	}
+	return a.decodeSuccess(body, "configuration was not deleted")
+}
+
+// GetTagConfig gets the variant of a configuration for a tag. Nacos 3 does
+// not serve tag variants over the HTTP API, it requires api_version v1.
+func (a *NacosAPI) GetTagConfig(ctx context.Context, opts *TagCfgOpts) (*nacos.Configuration, error) {
+	if a.isV3() {
+		return nil, fmt.Errorf("reading configuration tag variants requires api_version v1, the server uses api_version %s", a.apiVersion)
+	}
+	params := a.configParams(opts.DataID, opts.Group, opts.NamespaceID)
+	params.Set("tag", opts.Tag)
+	body, header, err := a.call(ctx, http.MethodGet, a.configPath(), params, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The content is the body, its metadata is in the headers.
+	return &nacos.Configuration{
+		DataID:           opts.DataID,
+		Group:            opts.Group,
+		NamespaceID:      opts.NamespaceID,
+		Content:          string(body),
+		Type:             header.Get("Config-Type"),
+		Md5:              header.Get("Content-MD5"),
+		EncryptedDataKey: header.Get("Encrypted-Data-Key"),
+	}, nil
+}
+
+// Listener is a client listening to a configuration, with the md5 of the
+// content the client holds.
+type Listener struct {
+	IP, DataID, Group, NamespaceID, Md5 string
+}
+
+// listenerStatus decodes the listeners of a configuration, keyed by client
+// ip, or the configurations listened by a client, keyed by group key.
+type listenerStatus struct {
+	// Nacos 2 misspells the field.
+	Lisenters map[string]string `json:"lisentersGroupkeyStatus"`
+	Listeners map[string]string `json:"listenersStatus"`
+}
+
+func (a *NacosAPI) getListenerStatus(ctx context.Context, path string, params url.Values) (map[string]string, error) {
+	body, _, err := a.call(ctx, http.MethodGet, path, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	status := &listenerStatus{}
+	if a.isV3() {
+		err = decodeResult(body, 0, status)
+	} else if err = json.Unmarshal(body, status); err != nil {
+		err = fmt.Errorf("unable to decode response: %w", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if a.isV3() {
+		return status.Listeners, nil
+	}
+	return status.Lisenters, nil
+}
+
+// ListConfigListeners lists the clients listening to a configuration.
+func (a *NacosAPI) ListConfigListeners(ctx context.Context, opts *nacos.GetCfgOpts) ([]*Listener, error) {
+	path := "/v1/cs/configs/listener"
+	if a.isV3() {
+		path = "/v3/console/cs/config/listener"
+	}
+	status, err := a.getListenerStatus(ctx, path, a.configParams(opts.DataID, opts.Group, opts.NamespaceID))
+	if err != nil {
+		return nil, err
+	}
+	listeners := make([]*Listener, 0, len(status))
+	for ip, md5 := range status {
+		listeners = append(listeners, &Listener{IP: ip, DataID: opts.DataID, Group: opts.Group, NamespaceID: opts.NamespaceID, Md5: md5})
+	}
+	return listeners, nil
+}
+
+// ListClientListeners lists the configurations a client listens to.
+func (a *NacosAPI) ListClientListeners(ctx context.Context, ip string) ([]*Listener, error) {
+	path := "/v1/cs/listener"
+	if a.isV3() {
+		path = "/v3/console/cs/config/listener/ip"
+	}
+	status, err := a.getListenerStatus(ctx, path, url.Values{"ip": {ip}})
+	if err != nil {
+		return nil, err
+	}
+	listeners := make([]*Listener, 0, len(status))
+	for groupKey, md5 := range status {
+		dataID, group, namespaceID, err := parseGroupKey(groupKey)
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, &Listener{IP: ip, DataID: dataID, Group: group, NamespaceID: namespaceID, Md5: md5})
+	}
+	return listeners, nil
+}
+
+// groupKeyUnescaper reverses the escaping of the parts of a group key.
+var groupKeyUnescaper = strings.NewReplacer("%2B", "+", "%25", "%")
+
+// parseGroupKey parses the key Nacos identifies a configuration with, the
+// data id, group and optional namespace id joined by "+".
+func parseGroupKey(groupKey string) (dataID, group, namespaceID string, err error) {
+	parts := strings.Split(groupKey, "+")
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", "", "", fmt.Errorf("invalid group key %q", groupKey)
+	}
+	for i, part := range parts {
+		parts[i] = groupKeyUnescaper.Replace(part)
+	}
+	if len(parts) == 3 {
+		namespaceID = parts[2]
+	}
+	return parts[0], parts[1], namespaceID, nil
+}
+
+// SearchCfgOpts are the options to search configurations.
+type SearchCfgOpts struct {
+	// Search is "accurate" or "blur", which matches DataID and Group with
+	// "*" wildcards.
+	Search, DataID, Group, NamespaceID, AppName, Tags, Type string
+	// ConfigDetail only matches configurations whose content contains it.
+	ConfigDetail     string
+	PageNo, PageSize int
+}
+
+// SearchConfig searches configurations, the items include their content.
+func (a *NacosAPI) SearchConfig(ctx context.Context, opts *SearchCfgOpts) (*Page[nacos.Configuration], error) {
+	params := a.configParams(opts.DataID, opts.Group, opts.NamespaceID)
+	params.Set("search", opts.Search)
+	params.Set("appName", opts.AppName)
+	params.Set("pageNo", strconv.Itoa(opts.PageNo))
+	params.Set("pageSize", strconv.Itoa(opts.PageSize))
+	path := "/v1/cs/configs"
+	if a.isV3() {
+		path = "/v3/console/cs/config/list"
+		params.Set("configTags", opts.Tags)
+		params.Set("type", opts.Type)
+		params.Set("configDetail", opts.ConfigDetail)
+	} else {
+		params.Set("config_tags", opts.Tags)
+		params.Set("types", opts.Type)
+		params.Set("config_detail", opts.ConfigDetail)
+	}
+	body, _, err := a.call(ctx, http.MethodGet, path, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	found := &Page[apiConfiguration]{}
+	if a.isV3() {
+		err = decodeResult(body, 0, found)
+	} else if err = json.Unmarshal(body, found); err != nil {
+		err = fmt.Errorf("unable to decode response: %w", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	page := &Page[nacos.Configuration]{
+		TotalCount:     found.TotalCount,
+		PageNumber:     found.PageNumber,
+		PagesAvailable: found.PagesAvailable,
+		Items:          make([]*nacos.Configuration, 0, len(found.Items)),
+	}
+	for _, item := range found.Items {
+		config := item.configuration()
+		// The configurations listed by Nacos 3 do not include their content.
+		if a.isV3() {
+			if config, err = a.getConfig(ctx, config.DataID, config.Group, config.NamespaceID); err != nil {
+				return nil, err
+			}
+		}
+		page.Items = append(page.Items, &config)
+	}
+	return page, nil
+}
+
+// getConfig gets a configuration from the Nacos 3 console API.
+func (a *NacosAPI) getConfig(ctx context.Context, dataID, group, namespaceID string) (nacos.Configuration, error) {
+	body, _, err := a.call(ctx, http.MethodGet, a.configPath(), a.configParams(dataID, group, namespaceID), nil)
+	if err != nil {
+		return nacos.Configuration{}, err
+	}
+	config := &apiConfiguration{}
+	if err := decodeResult(body, 0, config); err != nil {
+		return nacos.Configuration{}, err
+	}
+	return config.configuration(), nil
+}
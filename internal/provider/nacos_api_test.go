@@ -0,0 +1,371 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/joelee2012/go-nacos"
+)
+
+// testNacosServer serves the login of the Nacos HTTP API and records the
+// other requests, which are answered by handle.
+type testNacosServer struct {
+	*httptest.Server
+	logins   int
+	requests []*http.Request
+	forms    []url.Values
+}
+
+func newTestNacosServer(t *testing.T, handle func(w http.ResponseWriter, r *http.Request)) *testNacosServer {
+	s := &testNacosServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unable to parse form: %s", err)
+		}
+		if r.URL.Path == "/v1/auth/login" || r.URL.Path == "/v3/auth/user/login" {
+			s.logins++
+			if r.PostForm.Get("username") != "nacos" || r.PostForm.Get("password") != "secret" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, `{"accessToken":"token","tokenTtl":18000}`)
+			return
+		}
+		if r.URL.Query().Get("accessToken") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		s.requests = append(s.requests, r)
+		s.forms = append(s.forms, r.Form)
+		handle(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestNacosAPIPublishConfig(t *testing.T) {
+	ctx := context.Background()
+	opts := &PublishCfgOpts{
+		DataID:           "cipher-aes-app.yaml",
+		Group:            "DEFAULT_GROUP",
+		NamespaceID:      "dev",
+		Content:          "encrypted",
+		Type:             "yaml",
+		Tags:             "a,b",
+		EncryptedDataKey: "key",
+		CasMd5:           "md5",
+		BetaIps:          "10.0.0.1",
+	}
+
+	v1 := newTestNacosServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "true")
+	})
+	api := NewNacosAPI(v1.URL, "nacos", "secret", "v1")
+	if err := api.PublishConfig(ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.PublishConfig(ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+	if v1.logins != 1 {
+		t.Errorf("expected the access token to be reused, got %d logins", v1.logins)
+	}
+	req, form := v1.requests[0], v1.forms[0]
+	if req.Method != http.MethodPost || req.URL.Path != "/v1/cs/configs" {
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+	}
+	for key, want := range map[string]string{"dataId": "cipher-aes-app.yaml", "group": "DEFAULT_GROUP", "tenant": "dev", "config_tags": "a,b", "encryptedDataKey": "key"} {
+		if got := form.Get(key); got != want {
+			t.Errorf("v1: expected %s %q, got %q", key, want, got)
+		}
+	}
+	if req.Header.Get("casMd5") != "md5" || req.Header.Get("betaIps") != "10.0.0.1" {
+		t.Errorf("expected casMd5 and betaIps headers, got %v", req.Header)
+	}
+
+	v3 := newTestNacosServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"code":0,"message":"success","data":true}`)
+	})
+	api = NewNacosAPI(v3.URL+"/", "nacos", "secret", "v3")
+	if err := api.PublishConfig(ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+	req, form = v3.requests[0], v3.forms[0]
+	if req.URL.Path != "/v3/console/cs/config" {
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+	}
+	for key, want := range map[string]string{"groupName": "DEFAULT_GROUP", "namespaceId": "dev", "configTags": "a,b"} {
+		if got := form.Get(key); got != want {
+			t.Errorf("v3: expected %s %q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestNacosAPIErrors(t *testing.T) {
+	ctx := context.Background()
+	server := newTestNacosServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Form.Get("dataId") {
+		case "missing":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":20004,"message":"config not found"}`)
+		case "conflict":
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"code":20002,"message":"Cas publish fail, server md5 may have changed."}`)
+		default:
+			fmt.Fprint(w, `{"code":20004,"message":"resource not found","data":null}`)
+		}
+	})
+	api := NewNacosAPI(server.URL, "nacos", "secret", "v3")
+	for dataID, notFound := range map[string]bool{"missing": true, "conflict": false, "other": true} {
+		err := api.PublishConfig(ctx, &PublishCfgOpts{DataID: dataID})
+		if err == nil {
+			t.Fatalf("%s: expected an error", dataID)
+		}
+		if IsNotFoundError(err) != notFound {
+			t.Errorf("%s: expected IsNotFoundError %t for %s", dataID, notFound, err)
+		}
+	}
+
+	if err := NewNacosAPI(server.URL, "nacos", "wrong", "v3").PublishConfig(ctx, &PublishCfgOpts{}); err == nil {
+		t.Error("expected an error for wrong credentials")
+	}
+}
+
+func TestNacosAPIUpdateUserPassword(t *testing.T) {
+	ctx := context.Background()
+	for version, response := range map[string]string{
+		"v1": `{"code":200,"message":"update user ok!","data":null}`,
+		"v3": `{"code":0,"message":"success","data":"update user ok!"}`,
+	} {
+		server := newTestNacosServer(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, response)
+		})
+		if err := NewNacosAPI(server.URL, "nacos", "secret", version).UpdateUserPassword(ctx, "user", "new"); err != nil {
+			t.Fatalf("%s: %s", version, err)
+		}
+		req, form := server.requests[0], server.forms[0]
+		if req.Method != http.MethodPut || form.Get("username") != "user" || form.Get("newPassword") != "new" {
+			t.Errorf("%s: unexpected request %s %s", version, req.Method, req.URL)
+		}
+	}
+}
+
+func TestNacosAPIHistory(t *testing.T) {
+	ctx := context.Background()
+	responses := map[string]map[string]string{
+		"v1": {
+			"/v1/cs/history": `{"totalCount":1,"pageNumber":1,"pagesAvailable":1,"pageItems":[{"id":"7","dataId":"app","group":"g","tenant":"dev","opType":"U         ","srcUser":"nacos","createdTime":"2024-05-04T16:00:00.000+0000","lastModifiedTime":1714838400000}]}`,
+		},
+		"v3": {
+			"/v3/console/cs/history/list": `{"code":0,"data":{"totalCount":1,"pageNumber":1,"pagesAvailable":1,"pageItems":[{"id":7,"dataId":"app","groupName":"g","namespaceId":"dev","opType":"U","srcUser":"nacos","createTime":1714838400000,"modifyTime":1714838400000}]}}`,
+			"/v3/console/cs/history":      `{"code":0,"data":{"id":7,"dataId":"app","groupName":"g","namespaceId":"dev","content":"a=1","md5":"md5","opType":"U","modifyTime":1714838400000}}`,
+		},
+	}
+	for version, paths := range responses {
+		server := newTestNacosServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Form.Get("nid") != "" && version == "v1" {
+				fmt.Fprint(w, `{"id":7,"dataId":"app","group":"g","tenant":"dev","content":"a=1","md5":"md5","opType":"U","lastModifiedTime":1714838400000}`)
+				return
+			}
+			fmt.Fprint(w, paths[r.URL.Path])
+		})
+		api := NewNacosAPI(server.URL, "nacos", "secret", version)
+		page, err := api.ListHistory(ctx, &ListHistoryOpts{DataID: "app", Group: "g", NamespaceID: "dev", PageNo: 1, PageSize: 10})
+		if err != nil {
+			t.Fatalf("%s: %s", version, err)
+		}
+		want := ConfigurationHistory{ID: 7, DataID: "app", Group: "g", NamespaceID: "dev", OpType: "U", SrcUser: "nacos", CreateTime: 1714838400000, ModifyTime: 1714838400000}
+		if page.PagesAvailable != 1 || len(page.Items) != 1 || *page.Items[0] != want {
+			t.Errorf("%s: unexpected page %+v", version, page)
+		}
+		history, err := api.GetHistory(ctx, &GetHistoryOpts{ID: 7, DataID: "app", Group: "g", NamespaceID: "dev"})
+		if err != nil {
+			t.Fatalf("%s: %s", version, err)
+		}
+		if history.Content != "a=1" || history.Md5 != "md5" || history.ModifyTime != 1714838400000 {
+			t.Errorf("%s: unexpected revision %+v", version, history)
+		}
+		if got := server.forms[1].Get("nid"); got != "7" {
+			t.Errorf("%s: expected nid 7, got %q", version, got)
+		}
+	}
+}
+
+func TestNacosAPIBetaConfig(t *testing.T) {
+	ctx := context.Background()
+	for version, want := range map[string]struct{ path, response string }{
+		"v1": {"/v1/cs/configs", `{"code":200,"message":"query beta ok","data":{"dataId":"app","group":"g","tenant":"dev","content":"a=2","md5":"md5","betaIps":"10.0.0.1,10.0.0.2"}}`},
+		"v3": {"/v3/console/cs/config/beta", `{"code":0,"message":"success","data":{"dataId":"app","groupName":"g","namespaceId":"dev","content":"a=2","md5":"md5","betaIps":"10.0.0.1,10.0.0.2"}}`},
+	} {
+		server := newTestNacosServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != want.path {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.Method == http.MethodDelete {
+				fmt.Fprint(w, `{"code":`+map[string]string{"v1": "200", "v3": "0"}[version]+`,"data":true}`)
+				return
+			}
+			fmt.Fprint(w, want.response)
+		})
+		api := NewNacosAPI(server.URL, "nacos", "secret", version)
+		config, err := api.GetBetaConfig(ctx, &nacos.GetCfgOpts{DataID: "app", Group: "g", NamespaceID: "dev"})
+		if err != nil {
+			t.Fatalf("%s: %s", version, err)
+		}
+		if config.Group != "g" || config.NamespaceID != "dev" || config.Content != "a=2" || config.BetaIps != "10.0.0.1,10.0.0.2" {
+			t.Errorf("%s: unexpected beta configuration %+v", version, config)
+		}
+		if err := api.DeleteBetaConfig(ctx, &nacos.DeleteCfgOpts{DataID: "app", Group: "g", NamespaceID: "dev"}); err != nil {
+			t.Fatalf("%s: %s", version, err)
+		}
+		if version == "v1" && server.forms[0].Get("beta") != "true" {
+			t.Errorf("expected the beta parameter, got %v", server.forms[0])
+		}
+	}
+
+	// A configuration without a beta has no data.
+	server := newTestNacosServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"code":200,"message":"query beta ok","data":null}`)
+	})
+	_, err := NewNacosAPI(server.URL, "nacos", "secret", "v1").GetBetaConfig(ctx, &nacos.GetCfgOpts{DataID: "app", Group: "g"})
+	if !IsNotFoundError(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestNacosAPIDeleteTagConfig(t *testing.T) {
+	ctx := context.Background()
+	for version, response := range map[string]string{"v1": "true", "v3": `{"code":0,"message":"success","data":true}`} {
+		server := newTestNacosServer(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, response)
+		})
+		api := NewNacosAPI(server.URL, "nacos", "secret", version)
+		if err := api.DeleteTagConfig(ctx, &TagCfgOpts{DataID: "app", Group: "g", Tag: "v2"}); err != nil {
+			t.Fatalf("%s: %s", version, err)
+		}
+		if req := server.requests[0]; req.Method != http.MethodDelete || req.URL.Path != api.configPath() || server.forms[0].Get("tag") != "v2" {
+			t.Errorf("%s: unexpected request %s %s", version, req.Method, req.URL)
+		}
+	}
+}
+
+func TestNacosAPIGetTagConfig(t *testing.T) {
+	ctx := context.Background()
+	server := newTestNacosServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Form.Get("tag") != "eu-west" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "config data not exist")
+			return
+		}
+		w.Header().Set("Config-Type", "properties")
+		w.Header().Set("Content-MD5", "md5")
+		fmt.Fprint(w, "region=eu-west")
+	})
+	api := NewNacosAPI(server.URL, "nacos", "secret", "v1")
+	config, err := api.GetTagConfig(ctx, &TagCfgOpts{DataID: "app", Group: "g", Tag: "eu-west"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Content != "region=eu-west" || config.Type != "properties" || config.Md5 != "md5" || config.Group != "g" {
+		t.Errorf("unexpected tag variant %+v", config)
+	}
+	if _, err := api.GetTagConfig(ctx, &TagCfgOpts{DataID: "app", Group: "g", Tag: "us-east"}); !IsNotFoundError(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if _, err := NewNacosAPI(server.URL, "nacos", "secret", "v3").GetTagConfig(ctx, &TagCfgOpts{DataID: "app", Tag: "eu-west"}); err == nil {
+		t.Error("expected an error on api_version v3")
+	}
+}
+
+func TestNacosAPIListeners(t *testing.T) {
+	ctx := context.Background()
+	responses := map[string]map[string]string{
+		"v1": {
+			"/v1/cs/configs/listener": `{"collectStatus":200,"lisentersGroupkeyStatus":{"10.0.0.1":"md5"}}`,
+			"/v1/cs/listener":         `{"collectStatus":200,"lisentersGroupkeyStatus":{"app+g+dev":"md5","a%2Bb+g":"md5"}}`,
+		},
+		"v3": {
+			"/v3/console/cs/config/listener":    `{"code":0,"data":{"queryType":"config","listenersStatus":{"10.0.0.1":"md5"}}}`,
+			"/v3/console/cs/config/listener/ip": `{"code":0,"data":{"queryType":"ip","listenersStatus":{"app+g+dev":"md5","a%2Bb+g":"md5"}}}`,
+		},
+	}
+	for version, paths := range responses {
+		server := newTestNacosServer(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, paths[r.URL.Path])
+		})
+		api := NewNacosAPI(server.URL, "nacos", "secret", version)
+		listeners, err := api.ListConfigListeners(ctx, &nacos.GetCfgOpts{DataID: "app", Group: "g", NamespaceID: "dev"})
+		if err != nil {
+			t.Fatalf("%s: %s", version, err)
+		}
+		if len(listeners) != 1 || *listeners[0] != (Listener{IP: "10.0.0.1", DataID: "app", Group: "g", NamespaceID: "dev", Md5: "md5"}) {
+			t.Errorf("%s: unexpected listeners %+v", version, listeners)
+		}
+		listeners, err = api.ListClientListeners(ctx, "10.0.0.1")
+		if err != nil {
+			t.Fatalf("%s: %s", version, err)
+		}
+		got := map[Listener]bool{}
+		for _, listener := range listeners {
+			got[*listener] = true
+		}
+		want := map[Listener]bool{
+			{IP: "10.0.0.1", DataID: "app", Group: "g", NamespaceID: "dev", Md5: "md5"}: true,
+			{IP: "10.0.0.1", DataID: "a+b", Group: "g", Md5: "md5"}:                     true,
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected listened configurations %v, got %v", version, want, got)
+		}
+	}
+	if _, _, _, err := parseGroupKey("app"); err == nil {
+		t.Error("expected an error parsing a group key without group")
+	}
+}
+
+func TestNacosAPISearchConfig(t *testing.T) {
+	ctx := context.Background()
+	opts := &SearchCfgOpts{Search: "blur", DataID: "payments-*", Group: "g", Tags: "payments", Type: "yaml", ConfigDetail: "routes", PageNo: 1, PageSize: 10}
+
+	v1 := newTestNacosServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"totalCount":1,"pageNumber":1,"pagesAvailable":1,"pageItems":[{"dataId":"payments-a.yaml","group":"g","tenant":"","content":"routes: []","type":"yaml"}]}`)
+	})
+	page, err := NewNacosAPI(v1.URL, "nacos", "secret", "v1").SearchConfig(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.TotalCount != 1 || len(page.Items) != 1 || page.Items[0].Content != "routes: []" {
+		t.Errorf("v1: unexpected page %+v", page)
+	}
+	for key, want := range map[string]string{"search": "blur", "dataId": "payments-*", "config_tags": "payments", "types": "yaml", "config_detail": "routes", "pageNo": "1"} {
+		if got := v1.forms[0].Get(key); got != want {
+			t.Errorf("v1: expected %s %q, got %q", key, want, got)
+		}
+	}
+
+	v3 := newTestNacosServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/console/cs/config/list" {
+			fmt.Fprint(w, `{"code":0,"data":{"totalCount":1,"pageNumber":1,"pagesAvailable":1,"pageItems":[{"dataId":"payments-a.yaml","groupName":"g","namespaceId":"public","type":"yaml"}]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"code":0,"data":{"dataId":"payments-a.yaml","groupName":"g","namespaceId":"public","content":"routes: []","type":"yaml","md5":"md5"}}`)
+	})
+	page, err = NewNacosAPI(v3.URL, "nacos", "secret", "v3").SearchConfig(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Content != "routes: []" || page.Items[0].Md5 != "md5" || page.Items[0].NamespaceID != "public" {
+		t.Errorf("v3: unexpected page %+v", page)
+	}
+	if got := v3.forms[1].Get("dataId"); got != "payments-a.yaml" {
+		t.Errorf("v3: expected the content of payments-a.yaml to be read, got %q", got)
+	}
+}
@@ -28,10 +28,41 @@ type NacosProvider struct {
 
 // NacosProviderModel describes the provider data model.
 type NacosProviderModel struct {
-	Host       types.String `tfsdk:"host"`
-	Username   types.String `tfsdk:"username"`
-	Password   types.String `tfsdk:"password"`
-	APIVersion types.String `tfsdk:"api_version"`
+	Host          types.String `tfsdk:"host"`
+	Username      types.String `tfsdk:"username"`
+	Password      types.String `tfsdk:"password"`
+	APIVersion    types.String `tfsdk:"api_version"`
+	EncryptionKey types.String `tfsdk:"encryption_key"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+}
+
+// NacosProviderData is passed to data sources and resources when they are
+// configured.
+type NacosProviderData struct {
+	Client *nacos.Client
+	// API calls the Nacos HTTP API for the operations Client does not
+	// support.
+	API *NacosAPI
+	// Cipher encrypts the content of cipher- data ids, it is nil when no
+	// encryption key is configured.
+	Cipher *ConfigurationCipher
+	// AdoptExisting is the default of the adopt_existing resource attribute.
+	AdoptExisting bool
+}
+
+// Values of the deletion_policy resource attribute.
+const (
+	DeletionPolicyDelete = "DELETE"
+	DeletionPolicyRetain = "RETAIN"
+)
+
+// AdoptExisting reports whether Create takes over an object which already
+// exists, the resource attribute overrides the provider setting.
+func AdoptExisting(resourceSetting types.Bool, providerSetting bool) bool {
+	if resourceSetting.IsNull() || resourceSetting.IsUnknown() {
+		return providerSetting
+	}
+	return resourceSetting.ValueBool()
 }
 
 func (p *NacosProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -62,6 +93,16 @@ func (p *NacosProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 					stringvalidator.OneOf("v1", "v3"),
 				},
 			},
+			"encryption_key": schema.StringAttribute{
+				MarkdownDescription: "AES key (16, 24 or 32 bytes) used to encrypt the content of configurations whose data id has the `cipher-aes-` prefix, compatible with the Nacos config encryption plugin. " +
+					"Set the value statically in the configuration, or use the `NACOS_ENCRYPTION_KEY` environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Default of the `adopt_existing` attribute of the `nacos_configuration`, `nacos_namespace`, `nacos_user` and `nacos_role` resources, default is `false`.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -114,6 +155,24 @@ func (p *NacosProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
+	if config.EncryptionKey.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("encryption_key"),
+			"Unknown Nacos Encryption Key",
+			"The provider cannot encrypt configurations as there is an unknown configuration value for the encryption key. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the NACOS_ENCRYPTION_KEY environment variable.",
+		)
+	}
+
+	if config.AdoptExisting.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("adopt_existing"),
+			"Unknown Adopt Existing Setting",
+			"The provider cannot decide whether to adopt existing objects as there is an unknown configuration value for adopt_existing. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -125,6 +184,7 @@ func (p *NacosProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	username := os.Getenv("NACOS_USERNAME")
 	password := os.Getenv("NACOS_PASSWORD")
 	apiVersion := os.Getenv("NACOS_API_VERSION")
+	encryptionKey := os.Getenv("NACOS_ENCRYPTION_KEY")
 
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
@@ -142,6 +202,10 @@ func (p *NacosProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		apiVersion = config.APIVersion.ValueString()
 	}
 
+	if !config.EncryptionKey.IsNull() {
+		encryptionKey = config.EncryptionKey.ValueString()
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -199,15 +263,41 @@ func (p *NacosProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 		return
 	}
+	data := &NacosProviderData{
+		Client:        client,
+		API:           NewNacosAPI(host, username, password, client.APIVersion),
+		AdoptExisting: config.AdoptExisting.ValueBool(),
+	}
+	if encryptionKey != "" {
+		keys, err := NewAESDataKeyManager(encryptionKey)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("encryption_key"),
+				"Invalid Nacos Encryption Key",
+				err.Error(),
+			)
+			return
+		}
+		data.Cipher = NewConfigurationCipher(keys)
+	}
 	// Example client configuration for data sources and resources
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = data
+	resp.ResourceData = data
 }
 
 func (p *NacosProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewNamespaceResource,
 		NewConfigurationResource,
+		NewConfigurationRollbackResource,
+		NewConfigurationBetaResource,
+		NewConfigurationGrayResource,
+		NewConfigurationTagVariantResource,
+		NewConfigurationImportResource,
+		NewConfigurationCloneResource,
+		NewConfigurationsResource,
+		NewConfigurationDirectoryResource,
+		NewConfigurationKeysResource,
 		NewUserResource,
 		NewRoleResource,
 		NewPermissionResource,
@@ -220,6 +310,11 @@ func (p *NacosProvider) DataSources(ctx context.Context) []func() datasource.Dat
 		NewNamespacesDataSource,
 		NewConfigurationDataSource,
 		NewConfigurationsDataSource,
+		NewConfigurationHistoryDataSource,
+		NewConfigurationExportDataSource,
+		NewConfigurationValueDataSource,
+		NewConfigurationListenersDataSource,
+		NewListenedConfigurationsDataSource,
 		NewUserDataSource,
 		NewRoleDataSource,
 		NewPermissionDataSource,
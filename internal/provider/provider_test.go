@@ -19,6 +19,10 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 
 var testClient *nacos.Client
 
+// testAPI calls the Nacos HTTP API for the operations testClient does not
+// support.
+var testAPI *NacosAPI
+
 func initTestClient(t *testing.T) {
 	if testClient != nil {
 		return
@@ -31,6 +35,7 @@ func initTestClient(t *testing.T) {
 		t.Fatalf("Failed to detect Nacos API version: %s", err.Error())
 	}
 	testClient = client
+	testAPI = NewNacosAPI(os.Getenv("NACOS_HOST"), os.Getenv("NACOS_USERNAME"), os.Getenv("NACOS_PASSWORD"), client.APIVersion)
 }
 
 func testAccPreCheck(t *testing.T) {
@@ -61,3 +66,19 @@ func setupTestConfiguration(t *testing.T, opts *nacos.CreateCfgOpts) {
 		}
 	})
 }
+
+func TestProviderSchema(t *testing.T) {
+	server, err := testAccProtoV6ProviderFactories["nacos"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diag := range resp.Diagnostics {
+		if diag.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("%s: %s", diag.Summary, diag.Detail)
+		}
+	}
+}
@@ -5,16 +5,20 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/joelee2012/go-nacos"
 )
@@ -22,6 +26,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ConfigurationResource{}
 var _ resource.ResourceWithImportState = &ConfigurationResource{}
+var _ resource.ResourceWithValidateConfig = &ConfigurationResource{}
+var _ resource.ResourceWithModifyPlan = &ConfigurationResource{}
 
 // var _ resource.ResourceWithIdentity = &ConfigurationResource{}
 
@@ -32,19 +38,38 @@ func NewConfigurationResource() resource.Resource {
 // ConfigurationResource defines the resource implementation.
 type ConfigurationResource struct {
 	client *nacos.Client
+	api    *NacosAPI
+	cipher *ConfigurationCipher
+	// adoptExisting is the provider default of adopt_existing.
+	adoptExisting bool
 }
 
 // ConfigurationResourceModel describes the resource data model.
 type ConfigurationResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	DataID      types.String `tfsdk:"data_id"`
-	Group       types.String `tfsdk:"group"`
-	Content     types.String `tfsdk:"content"`
-	NamespaceID types.String `tfsdk:"namespace_id"`
-	Type        types.String `tfsdk:"type"`
-	Application types.String `tfsdk:"application"`
-	Description types.String `tfsdk:"description"`
-	Tags        types.Set    `tfsdk:"tags"`
+	ID                       types.String  `tfsdk:"id"`
+	DataID                   types.String  `tfsdk:"data_id"`
+	Group                    types.String  `tfsdk:"group"`
+	Content                  types.String  `tfsdk:"content"`
+	NamespaceID              types.String  `tfsdk:"namespace_id"`
+	Type                     types.String  `tfsdk:"type"`
+	Application              types.String  `tfsdk:"application"`
+	Description              types.String  `tfsdk:"description"`
+	Tags                     types.Set     `tfsdk:"tags"`
+	ContentSchema            types.String  `tfsdk:"content_schema"`
+	StructuredContent        types.Dynamic `tfsdk:"structured_content"`
+	SensitiveContent         types.String  `tfsdk:"sensitive_content"`
+	ContentWO                types.String  `tfsdk:"content_wo"`
+	StoreContent             types.Bool    `tfsdk:"store_content_in_state"`
+	ContentSHA256            types.String  `tfsdk:"content_sha256"`
+	ForceOverwrite           types.Bool    `tfsdk:"force_overwrite"`
+	Md5                      types.String  `tfsdk:"md5"`
+	CreateTime               types.Int64   `tfsdk:"create_time"`
+	ModifyTime               types.Int64   `tfsdk:"modify_time"`
+	AdoptExisting            types.Bool    `tfsdk:"adopt_existing"`
+	DeletionPolicy           types.String  `tfsdk:"deletion_policy"`
+	PreventDestroyIfListened types.Bool    `tfsdk:"prevent_destroy_if_listened"`
+	ForceDestroy             types.Bool    `tfsdk:"force_destroy"`
+	WaitForPropagation       types.Object  `tfsdk:"wait_for_propagation"`
 }
 
 func (c *ConfigurationResourceModel) SetFromConfiguration(ctx context.Context, cfg *nacos.Configuration) diag.Diagnostics {
@@ -53,7 +78,35 @@ func (c *ConfigurationResourceModel) SetFromConfiguration(ctx context.Context, c
 	c.Group = types.StringValue(cfg.GetGroup())
 	c.NamespaceID = types.StringValue(cfg.GetNamespace())
 	c.Application = types.StringValue(cfg.Application)
-	c.Content = types.StringValue(cfg.Content)
+	// Imported resources start with the defaults of the optional settings.
+	if c.StoreContent.IsNull() {
+		c.StoreContent = types.BoolValue(true)
+	}
+	if c.ForceOverwrite.IsNull() {
+		c.ForceOverwrite = types.BoolValue(false)
+	}
+	if c.DeletionPolicy.IsNull() {
+		c.DeletionPolicy = types.StringValue(DeletionPolicyDelete)
+	}
+	if c.PreventDestroyIfListened.IsNull() {
+		c.PreventDestroyIfListened = types.BoolValue(false)
+	}
+	if c.ForceDestroy.IsNull() {
+		c.ForceDestroy = types.BoolValue(false)
+	}
+	switch {
+	case !c.StoreContent.ValueBool():
+		c.Content = types.StringNull()
+	case c.SensitiveContent.IsNull():
+		c.Content = types.StringValue(cfg.Content)
+	default:
+		c.SensitiveContent = types.StringValue(cfg.Content)
+		c.Content = types.StringNull()
+	}
+	c.ContentSHA256 = types.StringValue(ContentSHA256(cfg.Content))
+	c.Md5 = types.StringValue(cfg.Md5)
+	c.CreateTime = types.Int64Value(cfg.CreateTime)
+	c.ModifyTime = types.Int64Value(cfg.ModifyTime)
 	c.Description = types.StringValue(cfg.Description)
 	c.Type = types.StringValue(cfg.Type)
 	var diags diag.Diagnostics
@@ -67,11 +120,75 @@ func (c *ConfigurationResourceModel) SetFromConfiguration(ctx context.Context, c
 	return diags
 }
 
+// CasMd5 returns the md5 an update is published against, which is the one
+// recorded in private state, or empty to publish unconditionally when
+// force_overwrite is set.
+func (c *ConfigurationResourceModel) CasMd5(ctx context.Context, private PrivateState) (string, diag.Diagnostics) {
+	if c.ForceOverwrite.ValueBool() {
+		return "", nil
+	}
+	return GetPrivateMd5(ctx, private)
+}
+
+// ContentValue returns the content to publish, which is held by
+// content_wo when the content is not stored in state and by
+// sensitive_content in sensitive mode.
+func (c *ConfigurationResourceModel) ContentValue() string {
+	if !c.ContentWO.IsNull() {
+		return c.ContentWO.ValueString()
+	}
+	if !c.SensitiveContent.IsNull() {
+		return c.SensitiveContent.ValueString()
+	}
+	return c.Content.ValueString()
+}
+
+// SamePublished reports whether publishing the model would leave the
+// configuration on the server as described by other, unknown values are
+// never the same.
+func (c *ConfigurationResourceModel) SamePublished(other *ConfigurationResourceModel) bool {
+	return !c.ContentSHA256.IsUnknown() && c.ContentSHA256.Equal(other.ContentSHA256) &&
+		!c.Type.IsUnknown() && c.Type.Equal(other.Type) &&
+		!c.Application.IsUnknown() && c.Application.Equal(other.Application) &&
+		!c.Description.IsUnknown() && c.Description.Equal(other.Description) &&
+		!c.Tags.IsUnknown() && c.Tags.Equal(other.Tags)
+}
+
+// ConfiguredContent returns the content as configured, rendering
+// structured_content when it is set. The boolean result is false when the
+// content is not known yet.
+func (c *ConfigurationResourceModel) ConfiguredContent(contentType string) (string, bool, error) {
+	if !c.ContentWO.IsNull() {
+		return c.ContentWO.ValueString(), !c.ContentWO.IsUnknown(), nil
+	}
+	if !c.SensitiveContent.IsNull() {
+		return c.SensitiveContent.ValueString(), !c.SensitiveContent.IsUnknown(), nil
+	}
+	if c.StructuredContent.IsNull() {
+		return c.Content.ValueString(), !c.Content.IsUnknown(), nil
+	}
+	value, known, err := StructuredValue(c.StructuredContent)
+	if err != nil || !known {
+		return "", known, err
+	}
+	content, err := RenderStructuredContent(contentType, value)
+	return content, err == nil, err
+}
+
 func (c *ConfigurationResourceModel) TagsToString(ctx context.Context) (string, diag.Diagnostics) {
+	return JoinTags(ctx, c.Tags)
+}
+
+// JoinTags returns the tags in the comma separated form Nacos stores, null
+// or unknown tags are returned as an empty string.
+func JoinTags(ctx context.Context, set types.Set) (string, diag.Diagnostics) {
 	var diags diag.Diagnostics
+	if set.IsNull() || set.IsUnknown() {
+		return "", diags
+	}
 	var tags []string
-	elements := make([]types.String, 0, len(c.Tags.Elements()))
-	diags.Append(c.Tags.ElementsAs(ctx, &elements, false)...)
+	elements := make([]types.String, 0, len(set.Elements()))
+	diags.Append(set.ElementsAs(ctx, &elements, false)...)
 	if diags.HasError() {
 		return "", diags
 	}
@@ -106,8 +223,41 @@ func (r *ConfigurationResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 			"content": schema.StringAttribute{
-				MarkdownDescription: "Configuration content.",
-				Required:            true,
+				MarkdownDescription: "Configuration content. Exactly one of `content`, `structured_content`, `sensitive_content` or `content_wo` must be set, when `structured_content` is set this is the rendered content.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("structured_content"), path.MatchRoot("sensitive_content"), path.MatchRoot("content_wo")),
+				},
+				PlanModifiers: []planmodifier.String{
+					planContent(),
+				},
+			},
+			"sensitive_content": schema.StringAttribute{
+				MarkdownDescription: "Configuration content which is marked as sensitive and hidden from plan output, `content` is left empty when this is set.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"content_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only configuration content, it is never stored in Terraform state. Requires `store_content_in_state` to be `false` and Terraform 1.11 or later.",
+				Optional:            true,
+				WriteOnly:           true,
+			},
+			"store_content_in_state": schema.BoolAttribute{
+				MarkdownDescription: "Whether to store the configuration content in Terraform state, default is `true`. " +
+					"When `false` the content must be set with `content_wo`, only its SHA-256 is stored in `content_sha256` and changes are detected by comparing hashes.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"content_sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 of the configuration content.",
+				Computed:            true,
+			},
+			"structured_content": schema.DynamicAttribute{
+				MarkdownDescription: "Configuration content as a Terraform value, it is serialized into `content` according to `type`, which must be `json`, `yaml` or `properties`. " +
+					"Object keys are sorted, nested keys of `properties` content are joined with `.` and list elements are written as `key[index]`.",
+				Optional: true,
 			},
 			"group": schema.StringAttribute{
 				MarkdownDescription: "Configuration group, default is `DEFAULT_GROUP`.",
@@ -155,28 +305,263 @@ func (r *ConfigurationResource) Schema(ctx context.Context, req resource.SchemaR
 				ElementType:         types.StringType,
 				Optional:            true,
 			},
+			"content_schema": schema.StringAttribute{
+				MarkdownDescription: "JSON Schema document the configuration content must satisfy. The content is parsed according to `type`, which must be `json` or `yaml`, and validated at plan time.",
+				Optional:            true,
+			},
+			"force_overwrite": schema.BoolAttribute{
+				MarkdownDescription: "Whether to overwrite the configuration on update even if it was changed outside of Terraform since it was last read, default is `false`. " +
+					"Otherwise updates are published with the server md5 recorded during the last read and fail when the configuration has been changed in the meantime.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over a configuration which already exists on create instead of failing, its content and metadata are overwritten to match this resource. Defaults to the provider `adopt_existing` setting.",
+				Optional:            true,
+			},
+			"deletion_policy": schema.StringAttribute{
+				MarkdownDescription: "What happens to the configuration when the resource is destroyed, `DELETE` (default) deletes it from Nacos and `RETAIN` only removes it from Terraform state.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(DeletionPolicyDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(DeletionPolicyDelete, DeletionPolicyRetain),
+				},
+			},
+			"prevent_destroy_if_listened": schema.BoolAttribute{
+				MarkdownDescription: "Whether to refuse deleting the configuration while clients are listening to it, default is `false`. " +
+					"The listening clients are reported in the error, set `force_destroy` to delete it anyway.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete the configuration even if clients are listening to it when `prevent_destroy_if_listened` is `true`, default is `false`. " +
+					"It must be applied before the destroy to take effect.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"md5": schema.StringAttribute{
+				MarkdownDescription: "Configuration md5 reported by the server, it only changes when the configuration is published.",
+				Computed:            true,
+			},
+			"create_time": schema.Int64Attribute{
+				MarkdownDescription: "Configuration create time.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"modify_time": schema.Int64Attribute{
+				MarkdownDescription: "Configuration modify time, it only changes when the configuration is published.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"wait_for_propagation": schema.SingleNestedBlock{
+				MarkdownDescription: "Wait after an update until the clients listening to the configuration hold the published content, " +
+					"by polling the listeners until their md5 matches `md5`.",
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.StringAttribute{
+						MarkdownDescription: "How long to wait, as a duration like `30s` or `5m`, default is `5m`.",
+						Optional:            true,
+					},
+					"min_fraction": schema.Float64Attribute{
+						MarkdownDescription: "Fraction of the listeners which must hold the published content, between `0` and `1`, default is `1`.",
+						Optional:            true,
+						Validators: []validator.Float64{
+							float64validator.Between(0, 1),
+						},
+					},
+					"fail_on_timeout": schema.BoolAttribute{
+						MarkdownDescription: "Whether to fail when the timeout is reached, default is `true`. Otherwise the lagging listeners are reported as warning.",
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
 
+func (r *ConfigurationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ConfigurationResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.WaitForPropagation.IsNull() && !data.WaitForPropagation.IsUnknown() {
+		var wait WaitForPropagationModel
+		resp.Diagnostics.Append(data.WaitForPropagation.As(ctx, &wait, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !wait.Timeout.IsUnknown() {
+			if _, err := wait.TimeoutValue(); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("wait_for_propagation").AtName("timeout"),
+					"Invalid propagation timeout",
+					err.Error(),
+				)
+				return
+			}
+		}
+	}
+
+	if data.Type.IsUnknown() {
+		return
+	}
+
+	contentType := data.Type.ValueString()
+	if data.Type.IsNull() {
+		contentType = "text"
+	}
+
+	if !data.StructuredContent.IsNull() && contentType != "json" && contentType != "yaml" && contentType != "properties" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("structured_content"),
+			"Unsupported configuration type",
+			fmt.Sprintf("structured_content requires type to be json, yaml or properties, got %q.", contentType),
+		)
+		return
+	}
+
+	if !data.StoreContent.IsUnknown() && !data.ContentWO.IsUnknown() {
+		storeContent := data.StoreContent.IsNull() || data.StoreContent.ValueBool()
+		if !storeContent && data.ContentWO.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("store_content_in_state"),
+				"Missing write-only content",
+				"content_wo must be set when store_content_in_state is false.",
+			)
+			return
+		}
+		if storeContent && !data.ContentWO.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("content_wo"),
+				"Write-only content requires store_content_in_state",
+				"store_content_in_state must be false when content_wo is set.",
+			)
+			return
+		}
+	}
+
+	if data.ContentSchema.IsNull() || data.ContentSchema.IsUnknown() {
+		return
+	}
+
+	contentSchema, err := CompileContentSchema(data.ContentSchema.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content_schema"),
+			"Invalid content schema",
+			err.Error(),
+		)
+		return
+	}
+
+	if contentType != "json" && contentType != "yaml" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content_schema"),
+			"Unsupported configuration type",
+			fmt.Sprintf("content_schema requires type to be json or yaml, got %q.", contentType),
+		)
+		return
+	}
+
+	content, known, err := data.ConfiguredContent(contentType)
+	if err == nil && !known {
+		return
+	}
+	var doc any
+	if err == nil {
+		doc, err = ParseContent(contentType, content)
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"Invalid configuration content",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, violation := range ValidateContentSchema(contentSchema, doc) {
+		pointer := violation.Pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"Configuration content does not match content schema",
+			fmt.Sprintf("At %s: %s", pointer, violation.Message),
+		)
+	}
+}
+
+func (r *ConfigurationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan ConfigurationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Type.IsUnknown() {
+		return
+	}
+
+	// The hash of the configured content makes changes visible in the plan
+	// even when the content itself is not stored in state.
+	content, known, err := config.ConfiguredContent(plan.Type.ValueString())
+	if err != nil || !known {
+		return
+	}
+	plan.ContentSHA256 = types.StringValue(ContentSHA256(content))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_sha256"), plan.ContentSHA256)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var state ConfigurationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Changes which are not published, e.g. to content_schema, keep the
+	// server md5 and modify time so that dependents are not replanned.
+	if plan.SamePublished(&state) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("md5"), state.Md5)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("modify_time"), state.ModifyTime)...)
+	}
+}
+
 func (r *ConfigurationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*nacos.Client)
+	providerData, ok := req.ProviderData.(*NacosProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *nacos.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.api = providerData.API
+	r.cipher = providerData.Cipher
+	r.adoptExisting = providerData.AdoptExisting
 }
 
 func (r *ConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -184,6 +569,8 @@ func (r *ConfigurationResource) Create(ctx context.Context, req resource.CreateR
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// Write-only content is only available in the configuration
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content_wo"), &data.ContentWO)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -201,11 +588,12 @@ func (r *ConfigurationResource) Create(ctx context.Context, req resource.CreateR
 
 	config, err := r.client.GetConfig(ctx, getOpts)
 	id := BuildThreePartID(getOpts.NamespaceID, getOpts.Group, getOpts.DataID)
-	if err == nil && config != nil {
+	adopted := err == nil && config != nil
+	if adopted && !AdoptExisting(data.AdoptExisting, r.adoptExisting) {
 		resp.Diagnostics.AddError(
 			"Configuration already exists",
 			fmt.Sprintf("A configuration with namespace_id=%s,group=%s,data_id=%s already exists. "+
-				"Run `terraform import nacos_configuration.example %s` to manage it.", getOpts.NamespaceID, getOpts.Group, getOpts.DataID, id),
+				"Run `terraform import nacos_configuration.example %s` or set adopt_existing to manage it.", getOpts.NamespaceID, getOpts.Group, getOpts.DataID, id),
 		)
 		return
 	}
@@ -216,10 +604,10 @@ func (r *ConfigurationResource) Create(ctx context.Context, req resource.CreateR
 		)
 		return
 	}
-	opts := &nacos.CreateCfgOpts{
+	opts := &PublishCfgOpts{
 		DataID:      data.DataID.ValueString(),
 		Group:       data.Group.ValueString(),
-		Content:     data.Content.ValueString(),
+		Content:     data.ContentValue(),
 		NamespaceID: data.NamespaceID.ValueString(),
 		Type:        data.Type.ValueString(),
 		Application: data.Application.ValueString(),
@@ -235,7 +623,17 @@ func (r *ConfigurationResource) Create(ctx context.Context, req resource.CreateR
 		opts.Tags = tags
 	}
 
-	err = r.client.CreateConfig(ctx, opts)
+	content := opts.Content
+	if err := r.cipher.EncryptConfig(ctx, opts); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("data_id"),
+			"Unable to encrypt configuration",
+			err.Error(),
+		)
+		return
+	}
+
+	err = r.api.PublishConfig(ctx, opts)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create configuration",
@@ -243,11 +641,33 @@ func (r *ConfigurationResource) Create(ctx context.Context, req resource.CreateR
 		)
 		return
 	}
+	if adopted {
+		resp.Diagnostics.AddWarning(
+			"Adopted existing configuration",
+			fmt.Sprintf("The existing configuration with namespace_id=%s,group=%s,data_id=%s (md5 %s) is now managed by Terraform, "+
+				"its content and metadata were overwritten to match the configuration.", getOpts.NamespaceID, getOpts.Group, getOpts.DataID, config.Md5),
+		)
+	}
+	config, err = r.client.GetConfig(ctx, getOpts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read configuration after creating resource",
+			err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(SetPrivateMd5(ctx, resp.Private, config.Md5)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	data.ID = types.StringValue(id)
+	data.ContentSHA256 = types.StringValue(ContentSHA256(content))
+	data.Md5 = types.StringValue(config.Md5)
+	data.CreateTime = types.Int64Value(config.CreateTime)
+	data.ModifyTime = types.Int64Value(config.ModifyTime)
 	data.DataID = types.StringValue(opts.DataID)
 	data.Group = types.StringValue(opts.Group)
 	data.NamespaceID = types.StringValue(opts.NamespaceID)
-	data.Content = types.StringValue(opts.Content)
 	data.Type = types.StringValue(opts.Type)
 	data.Application = types.StringValue(opts.Application)
 	data.Description = types.StringValue(opts.Description)
@@ -303,7 +723,15 @@ func (r *ConfigurationResource) Read(ctx context.Context, req resource.ReadReque
 		}
 	}
 
+	if config.Content, err = r.cipher.DecryptContent(ctx, config.DataID, config.Content, config.EncryptedDataKey); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to decrypt configuration",
+			err.Error(),
+		)
+		return
+	}
 	resp.Diagnostics.Append(data.SetFromConfiguration(ctx, config)...)
+	resp.Diagnostics.Append(SetPrivateMd5(ctx, resp.Private, config.Md5)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -322,15 +750,23 @@ func (r *ConfigurationResource) Update(ctx context.Context, req resource.UpdateR
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// Write-only content is only available in the configuration
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content_wo"), &data.ContentWO)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	opts := &nacos.CreateCfgOpts{
+	// A known md5 means ModifyPlan found nothing to publish.
+	if !data.Md5.IsUnknown() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	opts := &PublishCfgOpts{
 		DataID:      data.DataID.ValueString(),
 		Group:       data.Group.ValueString(),
-		Content:     data.Content.ValueString(),
+		Content:     data.ContentValue(),
 		NamespaceID: data.NamespaceID.ValueString(),
 		Type:        data.Type.ValueString(),
 		Application: data.Application.ValueString(),
@@ -345,8 +781,31 @@ func (r *ConfigurationResource) Update(ctx context.Context, req resource.UpdateR
 		}
 		opts.Tags = tags
 	}
-	err := r.client.CreateConfig(ctx, opts)
+	if err := r.cipher.EncryptConfig(ctx, opts); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("data_id"),
+			"Unable to encrypt configuration",
+			err.Error(),
+		)
+		return
+	}
+	casMd5, diags := data.CasMd5(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	opts.CasMd5 = casMd5
+	err := r.api.PublishConfig(ctx, opts)
 	if err != nil {
+		if changedOutside(ctx, r.client, opts) {
+			resp.Diagnostics.AddError(
+				"Configuration changed outside of Terraform",
+				fmt.Sprintf("The configuration %q in group %q was modified since it was last read by Terraform. "+
+					"Run `terraform apply -refresh-only` to review the changes and plan again, or set force_overwrite to true to overwrite them.\n\n%s",
+					opts.DataID, opts.Group, err.Error()),
+			)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unable to update configuration",
 			err.Error(),
@@ -365,7 +824,15 @@ func (r *ConfigurationResource) Update(ctx context.Context, req resource.UpdateR
 		)
 		return
 	}
+	if config.Content, err = r.cipher.DecryptContent(ctx, config.DataID, config.Content, config.EncryptedDataKey); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to decrypt configuration",
+			err.Error(),
+		)
+		return
+	}
 	resp.Diagnostics.Append(data.SetFromConfiguration(ctx, config)...)
+	resp.Diagnostics.Append(SetPrivateMd5(ctx, resp.Private, config.Md5)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -377,6 +844,44 @@ func (r *ConfigurationResource) Update(ctx context.Context, req resource.UpdateR
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.WaitForPropagation.IsNull() {
+		return
+	}
+
+	// The configuration is published, a failed wait leaves it in state.
+	var wait WaitForPropagationModel
+	resp.Diagnostics.Append(data.WaitForPropagation.As(ctx, &wait, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(waitForPropagation(ctx, r.api, &nacos.GetCfgOpts{
+		DataID:      opts.DataID,
+		Group:       opts.Group,
+		NamespaceID: opts.NamespaceID,
+	}, config.Md5, &wait)...)
+}
+
+// ConfigurationGetter reads a configuration, it is implemented by
+// *nacos.Client.
+type ConfigurationGetter interface {
+	GetConfig(ctx context.Context, opts *nacos.GetCfgOpts) (*nacos.Configuration, error)
+}
+
+// changedOutside reports whether a failed compare-and-swap publish was
+// rejected because the server md5 no longer matches the recorded one.
+func changedOutside(ctx context.Context, client ConfigurationGetter, opts *PublishCfgOpts) bool {
+	if opts.CasMd5 == "" {
+		return false
+	}
+	config, err := client.GetConfig(ctx, &nacos.GetCfgOpts{
+		DataID:      opts.DataID,
+		Group:       opts.Group,
+		NamespaceID: opts.NamespaceID,
+	})
+	if err != nil {
+		return IsNotFoundError(err)
+	}
+	return config.Md5 != opts.CasMd5
 }
 
 func (r *ConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -394,6 +899,42 @@ func (r *ConfigurationResource) Delete(ctx context.Context, req resource.DeleteR
 		Group:       data.Group.ValueString(),
 		NamespaceID: data.NamespaceID.ValueString(),
 	}
+	if data.DeletionPolicy.ValueString() == DeletionPolicyRetain {
+		tflog.Warn(ctx, "retaining configuration", map[string]any{
+			"namespace_id": opts.NamespaceID,
+			"group":        opts.Group,
+			"data_id":      opts.DataID,
+		})
+		resp.Diagnostics.AddWarning(
+			"Configuration retained",
+			fmt.Sprintf("The configuration with namespace_id=%s,group=%s,data_id=%s was removed from Terraform state but not deleted from Nacos because deletion_policy is RETAIN.",
+				opts.NamespaceID, opts.Group, opts.DataID),
+		)
+		return
+	}
+	if data.PreventDestroyIfListened.ValueBool() && !data.ForceDestroy.ValueBool() {
+		listeners, err := r.api.ListConfigListeners(ctx, &nacos.GetCfgOpts{
+			DataID:      opts.DataID,
+			Group:       opts.Group,
+			NamespaceID: opts.NamespaceID,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read configuration listeners",
+				err.Error(),
+			)
+			return
+		}
+		if len(listeners) > 0 {
+			resp.Diagnostics.AddError(
+				"Configuration has listeners",
+				fmt.Sprintf("The configuration %q in group %q was not deleted because prevent_destroy_if_listened is true and %d clients are listening to it: %s. "+
+					"Set force_destroy to true and apply it to delete the configuration anyway.",
+					opts.DataID, opts.Group, len(listeners), strings.Join(listenerIPs(listeners), ", ")),
+			)
+			return
+		}
+	}
 	tflog.Debug(ctx, "deleting configuration", map[string]any{
 		"namespace_id": opts.NamespaceID,
 		"group":        opts.Group,
@@ -413,6 +954,67 @@ func (r *ConfigurationResource) ImportState(ctx context.Context, req resource.Im
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+// planContent returns a plan modifier which sets the planned content to the
+// serialized structured_content, or to null when sensitive_content or
+// content_wo is set.
+func planContent() planmodifier.String {
+	return contentModifier{}
+}
+
+type contentModifier struct{}
+
+func (m contentModifier) Description(_ context.Context) string {
+	return "Renders structured_content into content according to type, content is null when sensitive_content or content_wo is set."
+}
+
+func (m contentModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m contentModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var sensitive, contentWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sensitive_content"), &sensitive)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content_wo"), &contentWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !sensitive.IsNull() || !contentWO.IsNull() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+
+	var structured types.Dynamic
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("structured_content"), &structured)...)
+	if resp.Diagnostics.HasError() || structured.IsNull() {
+		return
+	}
+
+	var contentType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &contentType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value, known, err := StructuredValue(structured)
+	if err == nil && (!known || contentType.IsUnknown()) {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	var content string
+	if err == nil {
+		content, err = RenderStructuredContent(contentType.ValueString(), value)
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("structured_content"),
+			"Unable to render structured content",
+			err.Error(),
+		)
+		return
+	}
+	resp.PlanValue = types.StringValue(content)
+}
+
 func BuildThreePartID(namespaceID, group, dataID string) string {
 	return fmt.Sprintf("%s:%s:%s", namespaceID, group, dataID)
 }
@@ -424,3 +1026,22 @@ func ParseThreePartID(id string) (namespaceID, group, dataID string, err error)
 	}
 	return idParts[0], idParts[1], idParts[2], nil
 }
+
+// BuildFourPartID returns the ID of an object belonging to a configuration,
+// the configuration ID followed by the name of the object.
+func BuildFourPartID(namespaceID, group, dataID, name string) string {
+	return fmt.Sprintf("%s:%s", BuildThreePartID(namespaceID, group, dataID), name)
+}
+
+// parseFourPartID parses an ID built by BuildFourPartID, nameAttr is the
+// attribute holding the last part and is only used in the error message.
+func parseFourPartID(id, nameAttr string) (namespaceID, group, dataID, name string, err error) {
+	i := strings.LastIndex(id, ":")
+	if i >= 0 {
+		namespaceID, group, dataID, err = ParseThreePartID(id[:i])
+	}
+	if i < 0 || err != nil || i == len(id)-1 {
+		return "", "", "", "", fmt.Errorf("unexpected ID format (%q). expected <namespace_id>:<group>:<data_id>:<%s>", id, nameAttr)
+	}
+	return namespaceID, group, dataID, id[i+1:], nil
+}
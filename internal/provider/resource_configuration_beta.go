@@ -0,0 +1,350 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationBetaResource{}
+var _ resource.ResourceWithImportState = &ConfigurationBetaResource{}
+
+func NewConfigurationBetaResource() resource.Resource {
+	return &ConfigurationBetaResource{}
+}
+
+// ConfigurationBetaResource defines the resource implementation.
+type ConfigurationBetaResource struct {
+	api    *NacosAPI
+	cipher *ConfigurationCipher
+}
+
+// ConfigurationBetaResourceModel describes the resource data model.
+type ConfigurationBetaResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	ConfigurationVariantModel
+	BetaIPs types.Set `tfsdk:"beta_ips"`
+}
+
+func (c *ConfigurationBetaResourceModel) SetFromConfiguration(ctx context.Context, cfg *BetaConfiguration) diag.Diagnostics {
+	c.ConfigurationVariantModel.SetFromConfiguration(&cfg.Configuration)
+	ips, diags := types.SetValueFrom(ctx, types.StringType, SplitBetaIPs(cfg.BetaIps))
+	if diags.HasError() {
+		return diags
+	}
+	c.BetaIPs = ips
+	return diags
+}
+
+// SplitBetaIPs returns the ips of the comma separated list Nacos reports,
+// without surrounding spaces and empty entries.
+func SplitBetaIPs(betaIPs string) []string {
+	ips := []string{}
+	for _, ip := range strings.Split(betaIPs, ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// BetaIPsToString returns the beta ips in the comma separated form Nacos
+// expects.
+func (c *ConfigurationBetaResourceModel) BetaIPsToString(ctx context.Context) (string, diag.Diagnostics) {
+	var ips []string
+	diags := c.BetaIPs.ElementsAs(ctx, &ips, false)
+	sort.Strings(ips)
+	return strings.Join(ips, ","), diags
+}
+
+func (r *ConfigurationBetaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_beta"
+}
+
+func (r *ConfigurationBetaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Beta (gray) release of a configuration, the content is only delivered to the clients listed in `beta_ips`. " +
+			"To promote the beta, set its content on the `nacos_configuration` resource and remove this resource, destroying it stops the beta release.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource. In the format of `<namespace_id>:<group>:<data_id>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"data_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration data id.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Configuration group, default is `DEFAULT_GROUP`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("DEFAULT_GROUP"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration namespace id, default is empty string which means public namespace.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Beta configuration content.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Configuration type, default is `text`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("text"),
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"text", "json", "xml", "yaml", "html", "properties"}...),
+				},
+			},
+			"beta_ips": schema.SetAttribute{
+				MarkdownDescription: "IP addresses of the clients receiving the beta content.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"md5": schema.StringAttribute{
+				MarkdownDescription: "Beta configuration md5.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ConfigurationBetaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.api = providerData.API
+	r.cipher = providerData.Cipher
+}
+
+// publish publishes the beta configuration and reads it back into data.
+func (r *ConfigurationBetaResource) publish(ctx context.Context, data *ConfigurationBetaResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	betaIPs, d := data.BetaIPsToString(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	opts := &PublishCfgOpts{
+		DataID:      data.DataID.ValueString(),
+		Group:       data.Group.ValueString(),
+		NamespaceID: data.NamespaceID.ValueString(),
+		Content:     data.Content.ValueString(),
+		Type:        data.Type.ValueString(),
+		BetaIps:     betaIPs,
+	}
+	if err := r.cipher.EncryptConfig(ctx, opts); err != nil {
+		diags.AddAttributeError(
+			path.Root("data_id"),
+			"Unable to encrypt configuration",
+			err.Error(),
+		)
+		return diags
+	}
+	tflog.Debug(ctx, "publishing beta configuration", map[string]any{
+		"namespace_id": opts.NamespaceID,
+		"group":        opts.Group,
+		"data_id":      opts.DataID,
+		"beta_ips":     opts.BetaIps,
+	})
+	if err := r.api.PublishConfig(ctx, opts); err != nil {
+		diags.AddError(
+			"Unable to publish beta configuration",
+			err.Error(),
+		)
+		return diags
+	}
+	config, err := r.api.GetBetaConfig(ctx, &nacos.GetCfgOpts{
+		DataID:      opts.DataID,
+		Group:       opts.Group,
+		NamespaceID: opts.NamespaceID,
+	})
+	if err != nil {
+		diags.AddError(
+			"Unable to read beta configuration after publishing",
+			err.Error(),
+		)
+		return diags
+	}
+	data.ID = types.StringValue(BuildThreePartID(opts.NamespaceID, opts.Group, opts.DataID))
+	data.Md5 = types.StringValue(config.Md5)
+	return diags
+}
+
+func (r *ConfigurationBetaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConfigurationBetaResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.publish(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationBetaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConfigurationBetaResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespaceId, group, dataId, err := ParseThreePartID(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse configuration id",
+			err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, "read beta configuration", map[string]any{
+		"namespace_id": namespaceId,
+		"group":        group,
+		"data_id":      dataId,
+	})
+
+	config, err := r.api.GetBetaConfig(ctx, &nacos.GetCfgOpts{
+		NamespaceID: namespaceId,
+		Group:       group,
+		DataID:      dataId,
+	})
+	if err != nil {
+		// The beta is gone once it has been stopped in the console.
+		if IsNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to read beta configuration",
+			err.Error(),
+		)
+		return
+	}
+	if config.Content, err = r.cipher.DecryptContent(ctx, config.DataID, config.Content, config.EncryptedDataKey); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to decrypt configuration",
+			err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(data.SetFromConfiguration(ctx, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationBetaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConfigurationBetaResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.publish(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationBetaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConfigurationBetaResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &nacos.DeleteCfgOpts{
+		DataID:      data.DataID.ValueString(),
+		Group:       data.Group.ValueString(),
+		NamespaceID: data.NamespaceID.ValueString(),
+	}
+	tflog.Debug(ctx, "stopping beta configuration", map[string]any{
+		"namespace_id": opts.NamespaceID,
+		"group":        opts.Group,
+		"data_id":      opts.DataID,
+	})
+	if err := r.api.DeleteBetaConfig(ctx, opts); err != nil && !IsNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Unable to stop beta configuration",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ConfigurationBetaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
+)
+
+func TestSplitBetaIPs(t *testing.T) {
+	cases := map[string][]string{
+		"":                       {},
+		"10.0.0.1":               {"10.0.0.1"},
+		"10.0.0.1, 10.0.0.2 ,":   {"10.0.0.1", "10.0.0.2"},
+		" 10.0.0.1 ,,\t10.0.0.2": {"10.0.0.1", "10.0.0.2"},
+	}
+	for betaIPs, expected := range cases {
+		if got := SplitBetaIPs(betaIPs); !reflect.DeepEqual(got, expected) {
+			t.Errorf("SplitBetaIPs(%q) = %q, expected %q", betaIPs, got, expected)
+		}
+	}
+}
+
+func testAccConfigurationBetaConfig(content string, betaIPs string) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration" "test" {
+  data_id = "beta-test.properties"
+  content = "feature.enabled=false"
+  type    = "properties"
+}
+
+resource "nacos_configuration_beta" "test" {
+  data_id  = nacos_configuration.test.data_id
+  content  = "%s"
+  type     = "properties"
+  beta_ips = [%s]
+}
+`, content, betaIPs)
+}
+
+// testAccCheckBetaIPs checks the beta ips published to the server.
+func testAccCheckBetaIPs(dataId, want string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config, err := testAPI.GetBetaConfig(context.Background(), &nacos.GetCfgOpts{DataID: dataId, Group: "DEFAULT_GROUP"})
+		if err != nil {
+			return err
+		}
+		if config.BetaIps != want {
+			return fmt.Errorf("expected beta ips %q, got %q", want, config.BetaIps)
+		}
+		return nil
+	}
+}
+
+func TestAccConfigurationBetaResource(t *testing.T) {
+	resourceName := "nacos_configuration_beta.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigurationBetaConfig("feature.enabled=true", `"10.0.0.1"`),
+				// The test client is not a beta client, so it still gets the
+				// released content.
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckBetaIPs("beta-test.properties", "10.0.0.1"),
+					testAccCheckConfigurationContent("beta-test.properties", "feature.enabled=false"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("beta_ips"),
+						knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("10.0.0.1")}),
+					),
+					statecheck.ExpectKnownValue(
+						"nacos_configuration.test",
+						tfjsonpath.New("content"),
+						knownvalue.StringExact("feature.enabled=false"),
+					),
+				},
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfigurationBetaConfig("feature.enabled=canary", `"10.0.0.2", "10.0.0.1"`),
+				Check:  testAccCheckBetaIPs("beta-test.properties", "10.0.0.1,10.0.0.2"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content"),
+						knownvalue.StringExact("feature.enabled=canary"),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("beta_ips"),
+						knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("10.0.0.1"), knownvalue.StringExact("10.0.0.2")}),
+					),
+				},
+			},
+		},
+	})
+}
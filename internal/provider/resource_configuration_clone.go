@@ -0,0 +1,315 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationCloneResource{}
+var _ resource.ResourceWithValidateConfig = &ConfigurationCloneResource{}
+
+func NewConfigurationCloneResource() resource.Resource {
+	return &ConfigurationCloneResource{}
+}
+
+// ConfigurationCloneResource defines the resource implementation.
+type ConfigurationCloneResource struct {
+	client *nacos.Client
+	api    *NacosAPI
+	cipher *ConfigurationCipher
+}
+
+// ConfigurationCloneResourceModel describes the resource data model.
+type ConfigurationCloneResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	SourceNamespaceID types.String `tfsdk:"source_namespace_id"`
+	TargetNamespaceID types.String `tfsdk:"target_namespace_id"`
+	Group             types.String `tfsdk:"group"`
+	DataID            types.String `tfsdk:"data_id"`
+	Policy            types.String `tfsdk:"policy"`
+	DeleteOnDestroy   types.Bool   `tfsdk:"delete_on_destroy"`
+	ClonedIDs         types.List   `tfsdk:"cloned_ids"`
+	Overwritten       types.List   `tfsdk:"overwritten"`
+	Skipped           types.List   `tfsdk:"skipped"`
+	Failed            types.List   `tfsdk:"failed"`
+}
+
+func (r *ConfigurationCloneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_clone"
+}
+
+func (r *ConfigurationCloneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Clones the configurations of a namespace into another namespace. " +
+			"The configurations are cloned once when the resource is created, later changes of the source are not followed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource. In the format of `<source_namespace_id>:<target_namespace_id>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Namespace to clone from, empty string means public namespace.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Namespace to clone into, empty string means public namespace.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Only clone configurations of this group.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"data_id": schema.StringAttribute{
+				MarkdownDescription: "Only clone configurations with this data id, requires `group`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("group")),
+				},
+			},
+			"policy": schema.StringAttribute{
+				MarkdownDescription: "How to handle configurations which already exist in the target namespace, as the Nacos console: " +
+					"`ABORT` stops the clone at the first one, `SKIP` leaves them unchanged and `OVERWRITE` publishes the source content. Default is `ABORT`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(ConflictPolicyAbort),
+				Validators: []validator.String{
+					stringvalidator.OneOf(ConflictPolicyAbort, ConflictPolicySkip, ConflictPolicyOverwrite),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"delete_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Delete the configurations in `cloned_ids` when the resource is destroyed, default is `false`. " +
+					"Configurations in `overwritten` existed before the clone and are left in place.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"cloned_ids": schema.ListAttribute{
+				MarkdownDescription: "Configurations created in the target namespace, in the format of `<namespace_id>:<group>:<data_id>`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"overwritten": schema.ListAttribute{
+				MarkdownDescription: "Configurations which already existed in the target namespace and were overwritten with policy `OVERWRITE`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"skipped": schema.ListAttribute{
+				MarkdownDescription: "Configurations which were not cloned because they already exist or the clone was aborted.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"failed": schema.ListAttribute{
+				MarkdownDescription: "Configurations which could not be cloned, or which aborted the clone.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConfigurationCloneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.api = providerData.API
+	r.cipher = providerData.Cipher
+}
+
+func (r *ConfigurationCloneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ConfigurationCloneResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.SourceNamespaceID.IsUnknown() || data.TargetNamespaceID.IsUnknown() {
+		return
+	}
+	source, target := data.SourceNamespaceID.ValueString(), data.TargetNamespaceID.ValueString()
+	if source == target || (isPublicNamespace(source) && isPublicNamespace(target)) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("target_namespace_id"),
+			"Invalid target namespace",
+			"The target namespace must differ from the source namespace.",
+		)
+	}
+}
+
+func (r *ConfigurationCloneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConfigurationCloneResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source, target := data.SourceNamespaceID.ValueString(), data.TargetNamespaceID.ValueString()
+	list, err := listConfigurations(ctx, r.client, source, data.Group.ValueString(), data.DataID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read configurations",
+			err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, "cloning configurations", map[string]any{
+		"source_namespace_id": source,
+		"target_namespace_id": target,
+		"count":               len(list.Items),
+	})
+	result, diags := publishConfigurations(ctx, r.client, r.api, r.cipher, target, data.Policy.ValueString(), "clone", list.Items)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setStringLists(ctx, []stringList{
+		{&data.ClonedIDs, result.Created()},
+		{&data.Overwritten, result.Overwritten},
+		{&data.Skipped, result.Skipped},
+		{&data.Failed, result.Failed},
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = types.StringValue(fmt.Sprintf("%s:%s", source, target))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationCloneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConfigurationCloneResourceModel
+
+	// The clone is a one-off operation, the state records its result.
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationCloneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConfigurationCloneResourceModel
+
+	// Only delete_on_destroy can be updated in place.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationCloneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConfigurationCloneResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.DeleteOnDestroy.ValueBool() {
+		tflog.Debug(ctx, "removed configuration clone from state", map[string]any{"id": data.ID.ValueString()})
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(data.ClonedIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, id := range ids {
+		namespaceId, group, dataId, err := ParseThreePartID(id)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to parse configuration id",
+				err.Error(),
+			)
+			continue
+		}
+		tflog.Debug(ctx, "deleting cloned configuration", map[string]any{
+			"namespace_id": namespaceId,
+			"group":        group,
+			"data_id":      dataId,
+		})
+		err = r.client.DeleteConfig(ctx, &nacos.DeleteCfgOpts{DataID: dataId, Group: group, NamespaceID: namespaceId})
+		if err != nil && !IsNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"Unable to delete cloned configuration",
+				fmt.Sprintf("Unable to delete configuration %s: %s", id, err.Error()),
+			)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
+)
+
+func testAccConfigurationCloneConfig(deleteOnDestroy bool) string {
+	return fmt.Sprintf(`
+resource "nacos_namespace" "target" {
+  namespace_id = "clone-target"
+  name         = "clone-target"
+}
+
+resource "nacos_configuration_clone" "test" {
+  source_namespace_id = ""
+  target_namespace_id = nacos_namespace.target.namespace_id
+  group               = "clone-group"
+  delete_on_destroy   = %t
+}
+`, deleteOnDestroy)
+}
+
+func TestAccConfigurationCloneResource(t *testing.T) {
+	resourceName := "nacos_configuration_clone.test"
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: "clone-test.yaml", Group: "clone-group", Content: "a: 1", Type: "yaml"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			_, err := testClient.GetConfig(context.Background(), &nacos.GetCfgOpts{DataID: "clone-test.yaml", Group: "clone-group", NamespaceID: "clone-target"})
+			if err == nil {
+				return fmt.Errorf("cloned configuration still exists")
+			}
+			if !IsNotFoundError(err) {
+				return err
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "nacos_configuration_clone" "test" {
+  source_namespace_id = "public"
+  target_namespace_id = ""
+}
+`,
+				ExpectError: regexp.MustCompile("The target namespace must differ from the source namespace"),
+			},
+			{
+				Config: `
+resource "nacos_configuration_clone" "test" {
+  source_namespace_id = ""
+  target_namespace_id = "clone-target"
+  data_id             = "clone-test.yaml"
+}
+`,
+				ExpectError: regexp.MustCompile(`Attribute "group" must be specified when "data_id" is specified`),
+			},
+			{
+				Config: testAccConfigurationCloneConfig(false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("cloned_ids"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("clone-target:clone-group:clone-test.yaml")}),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("failed"),
+						knownvalue.ListSizeExact(0),
+					),
+				},
+			},
+			// Cloning again into the same namespace aborts at the existing configuration.
+			{
+				Config: testAccConfigurationCloneConfig(false) + `
+resource "nacos_configuration_clone" "again" {
+  source_namespace_id = ""
+  target_namespace_id = nacos_configuration_clone.test.target_namespace_id
+  group               = "clone-group"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"nacos_configuration_clone.again",
+						tfjsonpath.New("failed"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("clone-target:clone-group:clone-test.yaml")}),
+					),
+				},
+			},
+			{
+				Config: testAccConfigurationCloneConfig(true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("delete_on_destroy"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccConfigurationCloneResource_overwrite(t *testing.T) {
+	resourceName := "nacos_configuration_clone.test"
+	target := "clone-overwrite-target"
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: "existing.yaml", Group: "clone-overwrite-group", Content: "a: new", Type: "yaml"})
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: "fresh.yaml", Group: "clone-overwrite-group", Content: "b: new", Type: "yaml"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// Only the configuration created by the clone is deleted, the one
+		// which existed before keeps the cloned content.
+		CheckDestroy: func(s *terraform.State) error {
+			ctx := context.Background()
+			config, err := testClient.GetConfig(ctx, &nacos.GetCfgOpts{DataID: "existing.yaml", Group: "clone-overwrite-group", NamespaceID: target})
+			if err != nil {
+				return fmt.Errorf("overwritten configuration was deleted: %w", err)
+			}
+			if config.Content != "a: new" {
+				return fmt.Errorf("expected overwritten content %q, got %q", "a: new", config.Content)
+			}
+			_, err = testClient.GetConfig(ctx, &nacos.GetCfgOpts{DataID: "fresh.yaml", Group: "clone-overwrite-group", NamespaceID: target})
+			if err == nil {
+				return fmt.Errorf("cloned configuration still exists")
+			}
+			if !IsNotFoundError(err) {
+				return err
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					ctx := context.Background()
+					if err := testClient.CreateNamespace(ctx, &nacos.NsOpts{ID: target, Name: target}); err != nil {
+						t.Fatalf("Error creating namespace: %s", err)
+					}
+					t.Cleanup(func() {
+						if err := testClient.DeleteNamespace(ctx, target); err != nil {
+							t.Errorf("Error deleting namespace: %s", err)
+						}
+					})
+					existing := &nacos.CreateCfgOpts{DataID: "existing.yaml", Group: "clone-overwrite-group", NamespaceID: target, Content: "a: old", Type: "yaml"}
+					if err := testClient.CreateConfig(ctx, existing); err != nil {
+						t.Fatalf("Error creating configuration: %s", err)
+					}
+					t.Cleanup(func() {
+						err := testClient.DeleteConfig(ctx, &nacos.DeleteCfgOpts{DataID: existing.DataID, Group: existing.Group, NamespaceID: target})
+						if err != nil {
+							t.Errorf("Error deleting configuration: %s", err)
+						}
+					})
+				},
+				Config: fmt.Sprintf(`
+resource "nacos_configuration_clone" "test" {
+  source_namespace_id = ""
+  target_namespace_id = "%s"
+  group               = "clone-overwrite-group"
+  policy              = "OVERWRITE"
+  delete_on_destroy   = true
+}
+`, target),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("cloned_ids"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact(target + ":clone-overwrite-group:fresh.yaml")}),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("overwritten"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact(target + ":clone-overwrite-group:existing.yaml")}),
+					),
+				},
+			},
+		},
+	})
+}
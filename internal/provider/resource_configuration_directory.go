@@ -0,0 +1,567 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationDirectoryResource{}
+var _ resource.ResourceWithModifyPlan = &ConfigurationDirectoryResource{}
+
+func NewConfigurationDirectoryResource() resource.Resource {
+	return &ConfigurationDirectoryResource{}
+}
+
+// ConfigurationDirectoryResource defines the resource implementation.
+type ConfigurationDirectoryResource struct {
+	client *nacos.Client
+	api    *NacosAPI
+	cipher *ConfigurationCipher
+}
+
+// ConfigurationDirectoryResourceModel describes the resource data model.
+type ConfigurationDirectoryResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Path        types.String `tfsdk:"path"`
+	NamespaceID types.String `tfsdk:"namespace_id"`
+	Prune       types.Bool   `tfsdk:"prune"`
+	Files       types.Map    `tfsdk:"files"`
+}
+
+// ConfigurationFileModel describes a configuration synced from a file,
+// keyed by `<group>/<data_id>`.
+type ConfigurationFileModel struct {
+	Group         types.String `tfsdk:"group"`
+	DataID        types.String `tfsdk:"data_id"`
+	Type          types.String `tfsdk:"type"`
+	ContentSHA256 types.String `tfsdk:"content_sha256"`
+}
+
+var configurationFileAttrTypes = map[string]attr.Type{
+	"group":          types.StringType,
+	"data_id":        types.StringType,
+	"type":           types.StringType,
+	"content_sha256": types.StringType,
+}
+
+// LocalConfiguration is a configuration read from a directory.
+type LocalConfiguration struct {
+	Group   string
+	DataID  string
+	Type    string
+	Content string
+}
+
+// ReadConfigurationDirectory returns the configurations of a directory laid
+// out as `<group>/<data_id>`, keyed by `<group>/<data_id>`. The type is
+// inferred from the extension of the data id. Hidden files and directories
+// and files directly in the directory are ignored.
+func ReadConfigurationDirectory(root string) (map[string]*LocalConfiguration, error) {
+	groups, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	configs := make(map[string]*LocalConfiguration)
+	for _, group := range groups {
+		if !group.IsDir() || strings.HasPrefix(group.Name(), ".") {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(root, group.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if strings.HasPrefix(file.Name(), ".") {
+				continue
+			}
+			name := filepath.Join(root, group.Name(), file.Name())
+			if file.IsDir() {
+				return nil, fmt.Errorf("unexpected directory %s, expected <group>/<data_id> files", name)
+			}
+			content, err := os.ReadFile(name)
+			if err != nil {
+				return nil, err
+			}
+			configs[group.Name()+"/"+file.Name()] = &LocalConfiguration{
+				Group:   group.Name(),
+				DataID:  file.Name(),
+				Type:    configurationTypeOf(file.Name()),
+				Content: string(content),
+			}
+		}
+	}
+	return configs, nil
+}
+
+// filesValue returns the files attribute value of the configuration files.
+func filesValue(ctx context.Context, files map[string]ConfigurationFileModel) (types.Map, diag.Diagnostics) {
+	return types.MapValueFrom(ctx, types.ObjectType{AttrTypes: configurationFileAttrTypes}, files)
+}
+
+// filesOf returns the configuration files of the files attribute.
+func filesOf(ctx context.Context, value types.Map) (map[string]ConfigurationFileModel, diag.Diagnostics) {
+	files := make(map[string]ConfigurationFileModel)
+	if value.IsNull() || value.IsUnknown() {
+		return files, nil
+	}
+	diags := value.ElementsAs(ctx, &files, false)
+	return files, diags
+}
+
+func localFiles(configs map[string]*LocalConfiguration) map[string]ConfigurationFileModel {
+	files := make(map[string]ConfigurationFileModel, len(configs))
+	for key, cfg := range configs {
+		files[key] = ConfigurationFileModel{
+			Group:         types.StringValue(cfg.Group),
+			DataID:        types.StringValue(cfg.DataID),
+			Type:          types.StringValue(cfg.Type),
+			ContentSHA256: types.StringValue(ContentSHA256(cfg.Content)),
+		}
+	}
+	return files
+}
+
+func (r *ConfigurationDirectoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_directory"
+}
+
+func (r *ConfigurationDirectoryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Keeps the configurations of a namespace in sync with a local directory laid out as `<group>/<data_id>` files. " +
+			"The type of each configuration is inferred from the extension of the data id, `text` when it is not a configuration type. " +
+			"Hidden files and directories and files directly in `path` are ignored. Files which fail to sync are reported as errors, the other files are synced and the failed files are synced again on the next apply.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource. In the format of `<namespace_id>:<path>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Local directory holding the configurations.",
+				Required:            true,
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration namespace id, default is empty string which means public namespace.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"prune": schema.BoolAttribute{
+				MarkdownDescription: "Delete the configurations of the managed groups which do not exist in the directory, default is `false`. " +
+					"A group is managed when its folder in the directory holds a configuration.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"files": schema.MapNestedAttribute{
+				MarkdownDescription: "Synced configurations keyed by `<group>/<data_id>`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group": schema.StringAttribute{
+							MarkdownDescription: "Configuration group.",
+							Computed:            true,
+						},
+						"data_id": schema.StringAttribute{
+							MarkdownDescription: "Configuration data id.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Configuration type.",
+							Computed:            true,
+						},
+						"content_sha256": schema.StringAttribute{
+							MarkdownDescription: "The hex encoded sha256 of the configuration content.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ConfigurationDirectoryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.api = providerData.API
+	r.cipher = providerData.Cipher
+}
+
+func (r *ConfigurationDirectoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to sync on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan ConfigurationDirectoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Path.IsUnknown() {
+		return
+	}
+
+	// Plan the files of the directory, so that the plan shows the change of
+	// each file.
+	configs, err := ReadConfigurationDirectory(plan.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("path"),
+			"Unable to read configuration directory",
+			err.Error(),
+		)
+		return
+	}
+	files, diags := filesValue(ctx, localFiles(configs))
+	resp.Diagnostics.Append(diags...)
+	plan.Files = files
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// readDirectory reads the directory of data, which must hold the files
+// planned.
+func (r *ConfigurationDirectoryResource) readDirectory(ctx context.Context, data *ConfigurationDirectoryResourceModel) (map[string]*LocalConfiguration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	configs, err := ReadConfigurationDirectory(data.Path.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("path"),
+			"Unable to read configuration directory",
+			err.Error(),
+		)
+		return nil, diags
+	}
+	if data.Files.IsUnknown() {
+		return configs, diags
+	}
+	files, d := filesValue(ctx, localFiles(configs))
+	diags.Append(d...)
+	if !diags.HasError() && !files.Equal(data.Files) {
+		diags.AddAttributeError(
+			path.Root("path"),
+			"Configuration directory changed",
+			"The files of the directory changed after the plan was made. Run terraform apply again to sync the current files.",
+		)
+	}
+	return configs, diags
+}
+
+// sync publishes the files of the directory which differ from the prior
+// files and deletes the prior files which no longer exist. Failures are
+// reported as errors, the failed files keep their prior value so that they
+// are synced again on the next apply.
+func (r *ConfigurationDirectoryResource) sync(ctx context.Context, data *ConfigurationDirectoryResourceModel, configs map[string]*LocalConfiguration, prior map[string]ConfigurationFileModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	namespaceId := data.NamespaceID.ValueString()
+	files := localFiles(configs)
+	var notPublished, notDeleted, notPruned []string
+
+	keys := make([]string, 0, len(configs))
+	for key := range configs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		cfg := configs[key]
+		old, ok := prior[key]
+		if ok && old.Type.Equal(files[key].Type) && old.ContentSHA256.Equal(files[key].ContentSHA256) {
+			continue
+		}
+		opts := &PublishCfgOpts{
+			DataID:      cfg.DataID,
+			Group:       cfg.Group,
+			NamespaceID: namespaceId,
+			Content:     cfg.Content,
+			Type:        cfg.Type,
+		}
+		err := r.cipher.EncryptConfig(ctx, opts)
+		if err == nil {
+			tflog.Debug(ctx, "publishing configuration", map[string]any{
+				"namespace_id": namespaceId,
+				"group":        cfg.Group,
+				"data_id":      cfg.DataID,
+			})
+			err = r.api.PublishConfig(ctx, opts)
+		}
+		if err != nil {
+			if ok {
+				files[key] = old
+			} else {
+				delete(files, key)
+			}
+			notPublished = append(notPublished, fmt.Sprintf("%s: %s", key, err.Error()))
+		}
+	}
+
+	priorKeys := make([]string, 0, len(prior))
+	for key := range prior {
+		if _, ok := configs[key]; !ok {
+			priorKeys = append(priorKeys, key)
+		}
+	}
+	sort.Strings(priorKeys)
+	for _, key := range priorKeys {
+		if err := r.deleteFile(ctx, namespaceId, prior[key]); err != nil {
+			files[key] = prior[key]
+			notDeleted = append(notDeleted, fmt.Sprintf("%s: %s", key, err.Error()))
+		}
+	}
+
+	if data.Prune.ValueBool() {
+		notPruned = r.prune(ctx, namespaceId, configs)
+	}
+
+	// The files which failed to sync differ from the plan.
+	if len(notPublished) > 0 {
+		diags.AddError(
+			"Configurations not published",
+			fmt.Sprintf("They are published again on the next apply.\n\n%s", strings.Join(notPublished, "\n")),
+		)
+	}
+	if len(notDeleted) > 0 {
+		diags.AddError(
+			"Configurations not deleted",
+			fmt.Sprintf("They are deleted again on the next apply.\n\n%s", strings.Join(notDeleted, "\n")),
+		)
+	}
+	if len(notPruned) > 0 {
+		diags.AddWarning(
+			"Configurations not pruned",
+			fmt.Sprintf("They are pruned again on the next apply which changes the directory.\n\n%s", strings.Join(notPruned, "\n")),
+		)
+	}
+
+	value, d := filesValue(ctx, files)
+	diags.Append(d...)
+	data.Files = value
+	data.ID = types.StringValue(fmt.Sprintf("%s:%s", namespaceId, data.Path.ValueString()))
+	return diags
+}
+
+// prune deletes the configurations of the groups of the directory which do
+// not exist in the directory, it returns the configurations which could not
+// be deleted.
+func (r *ConfigurationDirectoryResource) prune(ctx context.Context, namespaceId string, configs map[string]*LocalConfiguration) []string {
+	var notDeleted []string
+	groups := make(map[string]bool)
+	for _, cfg := range configs {
+		groups[cfg.Group] = true
+	}
+	for group := range groups {
+		list, err := listConfigurations(ctx, r.client, namespaceId, group, "")
+		if err != nil {
+			notDeleted = append(notDeleted, fmt.Sprintf("%s/*: %s", group, err.Error()))
+			continue
+		}
+		for _, cfg := range list.Items {
+			key := group + "/" + cfg.DataID
+			if _, ok := configs[key]; ok {
+				continue
+			}
+			err := r.deleteFile(ctx, namespaceId, ConfigurationFileModel{
+				Group:  types.StringValue(group),
+				DataID: types.StringValue(cfg.DataID),
+			})
+			if err != nil {
+				notDeleted = append(notDeleted, fmt.Sprintf("%s: %s", key, err.Error()))
+			}
+		}
+	}
+	return notDeleted
+}
+
+func (r *ConfigurationDirectoryResource) deleteFile(ctx context.Context, namespaceId string, file ConfigurationFileModel) error {
+	group, dataId := file.Group.ValueString(), file.DataID.ValueString()
+	tflog.Debug(ctx, "deleting configuration", map[string]any{
+		"namespace_id": namespaceId,
+		"group":        group,
+		"data_id":      dataId,
+	})
+	err := r.client.DeleteConfig(ctx, &nacos.DeleteCfgOpts{DataID: dataId, Group: group, NamespaceID: namespaceId})
+	if err != nil && !IsNotFoundError(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *ConfigurationDirectoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConfigurationDirectoryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configs, diags := r.readDirectory(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.sync(ctx, &data, configs, nil)...)
+	// A resource which fails to be created is replaced on the next apply,
+	// which would delete the synced configurations. Without state, they are
+	// published again instead.
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationDirectoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConfigurationDirectoryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := filesOf(ctx, data.Files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	namespaceId := data.NamespaceID.ValueString()
+	groups := make(map[string]bool)
+	for _, file := range prior {
+		groups[file.Group.ValueString()] = true
+	}
+
+	// Refresh the synced files from the server. With prune, the other
+	// configurations of the managed groups are added, so that the plan
+	// shows them being deleted.
+	files := make(map[string]ConfigurationFileModel)
+	for group := range groups {
+		list, err := listConfigurations(ctx, r.client, namespaceId, group, "")
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read configurations",
+				err.Error(),
+			)
+			return
+		}
+		for _, cfg := range list.Items {
+			key := group + "/" + cfg.DataID
+			if _, ok := prior[key]; !ok && !data.Prune.ValueBool() {
+				continue
+			}
+			content, err := r.cipher.DecryptContent(ctx, cfg.DataID, cfg.Content, cfg.EncryptedDataKey)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to decrypt configuration",
+					fmt.Sprintf("Unable to decrypt configuration %s: %s", BuildThreePartID(namespaceId, group, cfg.DataID), err.Error()),
+				)
+				return
+			}
+			files[key] = ConfigurationFileModel{
+				Group:         types.StringValue(group),
+				DataID:        types.StringValue(cfg.DataID),
+				Type:          types.StringValue(cfg.Type),
+				ContentSHA256: types.StringValue(ContentSHA256(content)),
+			}
+		}
+	}
+	value, diags := filesValue(ctx, files)
+	resp.Diagnostics.Append(diags...)
+	data.Files = value
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationDirectoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ConfigurationDirectoryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := filesOf(ctx, state.Files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configs, diags := r.readDirectory(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.sync(ctx, &data, configs, prior)...)
+
+	// Save updated data into Terraform state, also when some of the files
+	// failed to sync.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationDirectoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConfigurationDirectoryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	files, diags := filesOf(ctx, data.Files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for key, file := range files {
+		if err := r.deleteFile(ctx, data.NamespaceID.ValueString(), file); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("files").AtMapKey(key),
+				"Unable to delete configuration",
+				err.Error(),
+			)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
+)
+
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		name = filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadConfigurationDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"README.md":                    "ignored",
+		".git/config":                  "ignored",
+		"DEFAULT_GROUP/app.yml":        "a: 1",
+		"DEFAULT_GROUP/.gitkeep":       "",
+		"DEFAULT_GROUP/app.properties": "a=1",
+		"other/notes":                  "text",
+	})
+	configs, err := ReadConfigurationDirectory(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]LocalConfiguration{
+		"DEFAULT_GROUP/app.yml":        {Group: "DEFAULT_GROUP", DataID: "app.yml", Type: "yaml", Content: "a: 1"},
+		"DEFAULT_GROUP/app.properties": {Group: "DEFAULT_GROUP", DataID: "app.properties", Type: "properties", Content: "a=1"},
+		"other/notes":                  {Group: "other", DataID: "notes", Type: "text", Content: "text"},
+	}
+	if len(configs) != len(want) {
+		t.Fatalf("expected %d configurations, got %d", len(want), len(configs))
+	}
+	for key, cfg := range want {
+		if got, ok := configs[key]; !ok || *got != cfg {
+			t.Errorf("expected %s to be %+v, got %+v", key, cfg, got)
+		}
+	}
+
+	writeTestFiles(t, root, map[string]string{"other/nested/file": ""})
+	if _, err := ReadConfigurationDirectory(root); err == nil {
+		t.Error("expected error for nested directory")
+	}
+	if _, err := ReadConfigurationDirectory(filepath.Join(root, "missing")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
+
+func testAccConfigurationDirectoryConfig(root string, prune bool) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration_directory" "test" {
+  path  = %q
+  prune = %t
+}
+`, root, prune)
+}
+
+func TestAccConfigurationDirectoryResource(t *testing.T) {
+	resourceName := "nacos_configuration_directory.test"
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"dir-group/a.yaml":       "a: 1",
+		"dir-group/b.properties": "b=1",
+	})
+	// An unmanaged configuration in the managed group, deleted by prune.
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: "unmanaged.yaml", Group: "dir-group", Content: "u: 1", Type: "yaml"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigurationDirectoryConfig(root, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("files"),
+						knownvalue.MapSizeExact(2),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("files").AtMapKey("dir-group/a.yaml").AtMapKey("type"),
+						knownvalue.StringExact("yaml"),
+					),
+				},
+			},
+			{
+				PreConfig: func() {
+					writeTestFiles(t, root, map[string]string{"dir-group/a.yaml": "a: 2"})
+				},
+				Config: testAccConfigurationDirectoryConfig(root, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(
+							resourceName,
+							tfjsonpath.New("files").AtMapKey("dir-group/a.yaml").AtMapKey("content_sha256"),
+							knownvalue.StringExact(ContentSHA256("a: 2")),
+						),
+					},
+				},
+			},
+			{
+				Config: testAccConfigurationDirectoryConfig(root, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("files"),
+						knownvalue.MapSizeExact(2),
+					),
+				},
+				Check: func(_ *terraform.State) error {
+					_, err := testClient.GetConfig(context.Background(), &nacos.GetCfgOpts{DataID: "unmanaged.yaml", Group: "dir-group"})
+					if !IsNotFoundError(err) {
+						return fmt.Errorf("expected unmanaged configuration to be pruned, got %v", err)
+					}
+					return nil
+				},
+			},
+			// Configurations added outside of Terraform show up in the plan.
+			{
+				PreConfig: func() {
+					setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: "added.yaml", Group: "dir-group", Content: "x: 1", Type: "yaml"})
+				},
+				Config:             testAccConfigurationDirectoryConfig(root, true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccConfigurationDirectoryResource_partial(t *testing.T) {
+	if os.Getenv("NACOS_ENCRYPTION_KEY") != "" {
+		t.Skip("encrypted configurations only fail to publish without an encryption key")
+	}
+	resourceName := "nacos_configuration_directory.test"
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{"dir-partial/a.yaml": "a: 1"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigurationDirectoryConfig(root, false),
+			},
+			// The file which fails to publish is reported as error, the
+			// other file is published and saved.
+			{
+				PreConfig: func() {
+					writeTestFiles(t, root, map[string]string{
+						"dir-partial/a.yaml":        "a: 2",
+						"dir-partial/cipher-b.yaml": "b: 1",
+					})
+				},
+				Config:      testAccConfigurationDirectoryConfig(root, false),
+				ExpectError: regexp.MustCompile("Configurations not published"),
+			},
+			{
+				PreConfig: func() {
+					if err := os.Remove(filepath.Join(root, "dir-partial", "cipher-b.yaml")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccConfigurationDirectoryConfig(root, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("files"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"dir-partial/a.yaml": knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"group":          knownvalue.StringExact("dir-partial"),
+								"data_id":        knownvalue.StringExact("a.yaml"),
+								"type":           knownvalue.StringExact("yaml"),
+								"content_sha256": knownvalue.StringExact(ContentSHA256("a: 2")),
+							}),
+						}),
+					),
+				},
+				Check: func(_ *terraform.State) error {
+					config, err := testClient.GetConfig(context.Background(), &nacos.GetCfgOpts{DataID: "a.yaml", Group: "dir-partial"})
+					if err != nil {
+						return err
+					}
+					if config.Content != "a: 2" {
+						return fmt.Errorf("expected content %q, got %q", "a: 2", config.Content)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
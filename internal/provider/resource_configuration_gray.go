@@ -0,0 +1,445 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationGrayResource{}
+var _ resource.ResourceWithImportState = &ConfigurationGrayResource{}
+var _ resource.ResourceWithModifyPlan = &ConfigurationGrayResource{}
+
+func NewConfigurationGrayResource() resource.Resource {
+	return &ConfigurationGrayResource{}
+}
+
+// ConfigurationGrayResource defines the resource implementation.
+type ConfigurationGrayResource struct {
+	client *nacos.Client
+	api    *NacosAPI
+	cipher *ConfigurationCipher
+}
+
+// ConfigurationGrayResourceModel describes the resource data model.
+type ConfigurationGrayResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	ConfigurationVariantModel
+	GrayName       types.String `tfsdk:"gray_name"`
+	RuleType       types.String `tfsdk:"rule_type"`
+	RuleExpression types.String `tfsdk:"rule_expression"`
+}
+
+func (c *ConfigurationGrayResourceModel) SetFromBetaConfiguration(cfg *BetaConfiguration) {
+	c.ConfigurationVariantModel.SetFromConfiguration(&cfg.Configuration)
+	c.GrayName = types.StringValue(betaGrayName)
+	c.RuleType = types.StringValue("beta")
+	c.RuleExpression = types.StringValue(cfg.BetaIps)
+}
+
+// betaGrayName is the name Nacos gives to the gray rule of a beta release.
+const betaGrayName = "beta"
+
+// tagGrayNamePrefix prefixes the tag in the name Nacos gives to the gray rule
+// of a tag.
+const tagGrayNamePrefix = "tag_"
+
+// GrayName returns the name Nacos gives to the gray rule of the type and
+// expression.
+func GrayName(ruleType, expression string) string {
+	if ruleType == "beta" {
+		return betaGrayName
+	}
+	return tagGrayNamePrefix + expression
+}
+
+// ParseGrayName returns the type and, for tag rules, the expression of the
+// gray rule with the name.
+func ParseGrayName(grayName string) (ruleType, expression string, err error) {
+	if grayName == betaGrayName {
+		return "beta", "", nil
+	}
+	if tag, ok := strings.CutPrefix(grayName, tagGrayNamePrefix); ok && tag != "" {
+		return "tag", tag, nil
+	}
+	return "", "", fmt.Errorf("unsupported gray name %q, expected %q or %q followed by the tag", grayName, betaGrayName, tagGrayNamePrefix)
+}
+
+// BuildGrayID returns the ID of a gray rule, the configuration ID followed
+// by the gray name.
+func BuildGrayID(namespaceID, group, dataID, grayName string) string {
+	return BuildFourPartID(namespaceID, group, dataID, grayName)
+}
+
+func ParseGrayID(id string) (namespaceID, group, dataID, grayName string, err error) {
+	return parseFourPartID(id, "gray_name")
+}
+
+func (r *ConfigurationGrayResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_gray"
+}
+
+func (r *ConfigurationGrayResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Gray rule of a configuration, the gray content is delivered to the clients matching the rule. Requires Nacos 3.x. " +
+			"Nacos names the rule after its type, so a configuration has at most one `beta` rule and one `tag` rule per tag, and rules are published with the priority Nacos gives to their type. " +
+			"The content of `tag` rules cannot be read back from Nacos, changes made outside of Terraform are not detected.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource. In the format of `<namespace_id>:<group>:<data_id>:<gray_name>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"data_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration data id.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Configuration group, default is `DEFAULT_GROUP`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("DEFAULT_GROUP"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration namespace id, default is empty string which means public namespace.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gray_name": schema.StringAttribute{
+				MarkdownDescription: "Name Nacos gives to the gray rule, `beta` for `beta` rules and `tag_<rule_expression>` for `tag` rules.",
+				Computed:            true,
+			},
+			"rule_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the gray rule, `tag` (default) matches the client label `nacos.config.gray.label`, `beta` matches a comma separated list of client IPs.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("tag"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("tag", "beta"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rule_expression": schema.StringAttribute{
+				MarkdownDescription: "Expression of the gray rule, the label value for `tag` rules or the client IPs for `beta` rules. Changing the label of a `tag` rule replaces the rule.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							var ruleType types.String
+							resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rule_type"), &ruleType)...)
+							resp.RequiresReplace = ruleType.ValueString() != "beta"
+						},
+						"The label of a tag rule is part of its name, changing it replaces the rule.",
+						"The label of a `tag` rule is part of its name, changing it replaces the rule.",
+					),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Gray configuration content.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Configuration type, default is `text`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("text"),
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"text", "json", "xml", "yaml", "html", "properties"}...),
+				},
+			},
+			"md5": schema.StringAttribute{
+				MarkdownDescription: "Gray configuration md5.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ConfigurationGrayResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.api = providerData.API
+	r.cipher = providerData.Cipher
+}
+
+func (r *ConfigurationGrayResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy, or before the provider has been configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	if r.client.APIVersion != "v3" {
+		resp.Diagnostics.AddError(
+			"Unsupported Nacos API version",
+			fmt.Sprintf("nacos_configuration_gray requires Nacos 3.x (api_version v3), the server uses api_version %s. Use nacos_configuration_beta on older servers.", r.client.APIVersion),
+		)
+		return
+	}
+
+	var ruleType, expression types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rule_type"), &ruleType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rule_expression"), &expression)...)
+	if resp.Diagnostics.HasError() || ruleType.IsUnknown() || expression.IsUnknown() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("gray_name"), GrayName(ruleType.ValueString(), expression.ValueString()))...)
+}
+
+// publish publishes the gray configuration and reads it back into data.
+func (r *ConfigurationGrayResource) publish(ctx context.Context, data *ConfigurationGrayResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	opts := &PublishCfgOpts{
+		DataID:      data.DataID.ValueString(),
+		Group:       data.Group.ValueString(),
+		NamespaceID: data.NamespaceID.ValueString(),
+		Content:     data.Content.ValueString(),
+		Type:        data.Type.ValueString(),
+	}
+	// Nacos publishes the gray rules of betas and tags, and names them
+	// after their type.
+	if data.RuleType.ValueString() == "beta" {
+		opts.BetaIps = data.RuleExpression.ValueString()
+	} else {
+		opts.Tag = data.RuleExpression.ValueString()
+	}
+	grayName := GrayName(data.RuleType.ValueString(), data.RuleExpression.ValueString())
+	if err := r.cipher.EncryptConfig(ctx, opts); err != nil {
+		diags.AddAttributeError(
+			path.Root("data_id"),
+			"Unable to encrypt configuration",
+			err.Error(),
+		)
+		return diags
+	}
+	tflog.Debug(ctx, "publishing gray configuration", map[string]any{
+		"namespace_id": opts.NamespaceID,
+		"group":        opts.Group,
+		"data_id":      opts.DataID,
+		"gray_name":    grayName,
+	})
+	if err := r.api.PublishConfig(ctx, opts); err != nil {
+		diags.AddError(
+			"Unable to publish gray configuration",
+			err.Error(),
+		)
+		return diags
+	}
+	data.ID = types.StringValue(BuildGrayID(opts.NamespaceID, opts.Group, opts.DataID, grayName))
+	data.GrayName = types.StringValue(grayName)
+	if opts.Tag != "" {
+		// Tag rules cannot be read back, their md5 is the one of the
+		// published content.
+		data.Md5 = types.StringValue(contentMd5(opts.Content))
+		return diags
+	}
+	config, err := r.api.GetBetaConfig(ctx, &nacos.GetCfgOpts{
+		DataID:      opts.DataID,
+		Group:       opts.Group,
+		NamespaceID: opts.NamespaceID,
+	})
+	if err != nil {
+		diags.AddError(
+			"Unable to read gray configuration after publishing",
+			err.Error(),
+		)
+		return diags
+	}
+	data.Md5 = types.StringValue(config.Md5)
+	return diags
+}
+
+func (r *ConfigurationGrayResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConfigurationGrayResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.publish(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationGrayResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConfigurationGrayResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespaceId, group, dataId, grayName, err := ParseGrayID(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse gray configuration id",
+			err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, "read gray configuration", map[string]any{
+		"namespace_id": namespaceId,
+		"group":        group,
+		"data_id":      dataId,
+		"gray_name":    grayName,
+	})
+
+	ruleType, expression, err := ParseGrayName(grayName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse gray configuration id",
+			err.Error(),
+		)
+		return
+	}
+	if ruleType != "beta" {
+		// Nacos has no API to read the gray rule of a tag, keep the state
+		// and only fill in the id attributes of an import.
+		data.DataID = types.StringValue(dataId)
+		data.Group = types.StringValue(group)
+		if namespaceId != "" {
+			data.NamespaceID = types.StringValue(namespaceId)
+		}
+		data.GrayName = types.StringValue(grayName)
+		data.RuleType = types.StringValue(ruleType)
+		data.RuleExpression = types.StringValue(expression)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	config, err := r.api.GetBetaConfig(ctx, &nacos.GetCfgOpts{
+		NamespaceID: namespaceId,
+		Group:       group,
+		DataID:      dataId,
+	})
+	if err != nil {
+		if IsNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to read gray configuration",
+			err.Error(),
+		)
+		return
+	}
+	if config.Content, err = r.cipher.DecryptContent(ctx, config.DataID, config.Content, config.EncryptedDataKey); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to decrypt configuration",
+			err.Error(),
+		)
+		return
+	}
+	data.SetFromBetaConfiguration(config)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationGrayResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConfigurationGrayResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.publish(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationGrayResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConfigurationGrayResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataId, group, namespaceId := data.DataID.ValueString(), data.Group.ValueString(), data.NamespaceID.ValueString()
+	tflog.Debug(ctx, "deleting gray configuration", map[string]any{
+		"namespace_id": namespaceId,
+		"group":        group,
+		"data_id":      dataId,
+		"gray_name":    data.GrayName.ValueString(),
+	})
+	var err error
+	if data.RuleType.ValueString() == "beta" {
+		err = r.api.DeleteBetaConfig(ctx, &nacos.DeleteCfgOpts{DataID: dataId, Group: group, NamespaceID: namespaceId})
+	} else {
+		err = r.api.DeleteTagConfig(ctx, &TagCfgOpts{DataID: dataId, Group: group, NamespaceID: namespaceId, Tag: data.RuleExpression.ValueString()})
+	}
+	if err != nil && !IsNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Unable to delete gray configuration",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ConfigurationGrayResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,194 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestParseGrayID(t *testing.T) {
+	namespaceId, group, dataId, grayName, err := ParseGrayID(":DEFAULT_GROUP:app.yaml:v2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if namespaceId != "" || group != "DEFAULT_GROUP" || dataId != "app.yaml" || grayName != "v2" {
+		t.Errorf("unexpected parts %q %q %q %q", namespaceId, group, dataId, grayName)
+	}
+	for _, id := range []string{":DEFAULT_GROUP:app.yaml", ":DEFAULT_GROUP:app.yaml:", "v2"} {
+		if _, _, _, _, err := ParseGrayID(id); err == nil {
+			t.Errorf("expected error parsing %q", id)
+		}
+	}
+}
+
+func TestParseGrayName(t *testing.T) {
+	for grayName, want := range map[string][2]string{
+		"beta":    {"beta", ""},
+		"tag_v2":  {"tag", "v2"},
+		"tag_a_b": {"tag", "a_b"},
+	} {
+		ruleType, expression, err := ParseGrayName(grayName)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", grayName, err)
+		}
+		if ruleType != want[0] || expression != want[1] {
+			t.Errorf("ParseGrayName(%q) = %q, %q, expected %q, %q", grayName, ruleType, expression, want[0], want[1])
+		}
+		if ruleType == "tag" && GrayName(ruleType, expression) != grayName {
+			t.Errorf("GrayName(%q, %q) = %q, expected %q", ruleType, expression, GrayName(ruleType, expression), grayName)
+		}
+	}
+	for _, grayName := range []string{"tag_", "canary", ""} {
+		if _, _, err := ParseGrayName(grayName); err == nil {
+			t.Errorf("expected error parsing %q", grayName)
+		}
+	}
+}
+
+func testAccConfigurationGrayConfig(expression, content string) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration" "test" {
+  data_id = "gray-test.properties"
+  content = "feature.enabled=false"
+  type    = "properties"
+}
+
+resource "nacos_configuration_gray" "test" {
+  data_id         = nacos_configuration.test.data_id
+  rule_expression = "%s"
+  content         = "%s"
+  type            = "properties"
+}
+`, expression, content)
+}
+
+func TestAccConfigurationGrayResource(t *testing.T) {
+	resourceName := "nacos_configuration_gray.test"
+	skipBelowV3 := func() (bool, error) { return testClient.APIVersion != "v3", nil }
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc:    func() (bool, error) { return testClient.APIVersion == "v3", nil },
+				Config:      testAccConfigurationGrayConfig("v2", "feature.enabled=true"),
+				ExpectError: regexp.MustCompile("requires Nacos 3.x"),
+			},
+			{
+				SkipFunc: skipBelowV3,
+				Config:   testAccConfigurationGrayConfig("v2", "feature.enabled=true"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(":DEFAULT_GROUP:gray-test.properties:tag_v2"),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("gray_name"),
+						knownvalue.StringExact("tag_v2"),
+					),
+				},
+			},
+			// The content of tag rules cannot be read back.
+			{
+				SkipFunc:                skipBelowV3,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"content", "type", "md5"},
+			},
+			// Changing the label replaces the rule.
+			{
+				SkipFunc: skipBelowV3,
+				Config:   testAccConfigurationGrayConfig("v3", "feature.enabled=canary"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("gray_name"),
+						knownvalue.StringExact("tag_v3"),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content"),
+						knownvalue.StringExact("feature.enabled=canary"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccConfigurationGrayBetaConfig(ips string) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration" "test" {
+  data_id = "gray-beta-test.properties"
+  content = "feature.enabled=false"
+  type    = "properties"
+}
+
+resource "nacos_configuration_gray" "test" {
+  data_id         = nacos_configuration.test.data_id
+  rule_type       = "beta"
+  rule_expression = "%s"
+  content         = "feature.enabled=beta"
+  type            = "properties"
+}
+`, ips)
+}
+
+func TestAccConfigurationGrayResource_beta(t *testing.T) {
+	resourceName := "nacos_configuration_gray.test"
+	skipBelowV3 := func() (bool, error) { return testClient.APIVersion != "v3", nil }
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: skipBelowV3,
+				Config:   testAccConfigurationGrayBetaConfig("10.0.0.1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(":DEFAULT_GROUP:gray-beta-test.properties:beta"),
+					),
+				},
+				Check: testAccCheckBetaIPs("gray-beta-test.properties", "10.0.0.1"),
+			},
+			{
+				SkipFunc:          skipBelowV3,
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Changing the IPs of a beta rule updates it in place.
+			{
+				SkipFunc: skipBelowV3,
+				Config:   testAccConfigurationGrayBetaConfig("10.0.0.1,10.0.0.2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckBetaIPs("gray-beta-test.properties", "10.0.0.1,10.0.0.2"),
+			},
+		},
+	})
+}
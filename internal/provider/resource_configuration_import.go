@@ -0,0 +1,323 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationImportResource{}
+var _ resource.ResourceWithModifyPlan = &ConfigurationImportResource{}
+
+func NewConfigurationImportResource() resource.Resource {
+	return &ConfigurationImportResource{}
+}
+
+// ConfigurationImportResource defines the resource implementation.
+type ConfigurationImportResource struct {
+	client *nacos.Client
+	api    *NacosAPI
+	cipher *ConfigurationCipher
+}
+
+// ConfigurationImportResourceModel describes the resource data model.
+type ConfigurationImportResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	NamespaceID   types.String `tfsdk:"namespace_id"`
+	SourcePath    types.String `tfsdk:"source_path"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	Policy        types.String `tfsdk:"policy"`
+	ArchiveSha256 types.String `tfsdk:"archive_sha256"`
+	Succeeded     types.List   `tfsdk:"succeeded"`
+	Skipped       types.List   `tfsdk:"skipped"`
+	Failed        types.List   `tfsdk:"failed"`
+}
+
+// readArchive returns the archive configured by source_path or content_base64.
+func (m *ConfigurationImportResourceModel) readArchive() ([]byte, error) {
+	if !m.SourcePath.IsNull() {
+		return os.ReadFile(m.SourcePath.ValueString())
+	}
+	archive, err := base64.StdEncoding.DecodeString(m.ContentBase64.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode content_base64: %w", err)
+	}
+	return archive, nil
+}
+
+func (r *ConfigurationImportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_import"
+}
+
+func (r *ConfigurationImportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Imports the configurations of a ZIP archive in the layout of the Nacos console export, e.g. from the `nacos_configuration_export` data source. " +
+			"The archive is imported again when its sha256, `namespace_id` or `policy` changes. " +
+			"The imported configurations are not managed by this resource, destroying it leaves them in place.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource. In the format of `<namespace_id>:<archive_sha256>`.",
+				Computed:            true,
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Namespace to import into, default is empty string which means public namespace.",
+				Optional:            true,
+			},
+			"source_path": schema.StringAttribute{
+				MarkdownDescription: "Local path of the archive. Exactly one of `source_path` or `content_base64` must be set. " +
+					"The archive is read during apply when the file does not exist at plan time, e.g. when it is written by another resource of the same apply.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("content_base64")),
+				},
+			},
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "The archive encoded in base64.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"policy": schema.StringAttribute{
+				MarkdownDescription: "How to handle configurations which already exist, as the Nacos console: " +
+					"`ABORT` stops the import at the first one, `SKIP` leaves them unchanged and `OVERWRITE` publishes the archive content. Default is `ABORT`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(ConflictPolicyAbort),
+				Validators: []validator.String{
+					stringvalidator.OneOf(ConflictPolicyAbort, ConflictPolicySkip, ConflictPolicyOverwrite),
+				},
+			},
+			"archive_sha256": schema.StringAttribute{
+				MarkdownDescription: "The hex encoded sha256 of the imported archive.",
+				Computed:            true,
+			},
+			"succeeded": schema.ListAttribute{
+				MarkdownDescription: "Configurations published by the import, in the format of `<namespace_id>:<group>:<data_id>`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"skipped": schema.ListAttribute{
+				MarkdownDescription: "Configurations which were not published because they already exist or the import was aborted.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"failed": schema.ListAttribute{
+				MarkdownDescription: "Configurations which could not be published, or which aborted the import.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ConfigurationImportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.api = providerData.API
+	r.cipher = providerData.Cipher
+}
+
+func (r *ConfigurationImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to import on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan ConfigurationImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	known := !plan.SourcePath.IsUnknown() && !plan.ContentBase64.IsUnknown()
+	var archive []byte
+	var err error
+	if known {
+		archive, err = plan.readArchive()
+	}
+	// The archive may be produced during apply, it is read then.
+	if !known || errors.Is(err, fs.ErrNotExist) {
+		plan.ID = types.StringUnknown()
+		plan.ArchiveSha256 = types.StringUnknown()
+		plan.Succeeded = types.ListUnknown(types.StringType)
+		plan.Skipped = types.ListUnknown(types.StringType)
+		plan.Failed = types.ListUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read configuration archive",
+			err.Error(),
+		)
+		return
+	}
+	sum := sha256.Sum256(archive)
+	plan.ArchiveSha256 = types.StringValue(hex.EncodeToString(sum[:]))
+
+	if !req.State.Raw.IsNull() {
+		var state ConfigurationImportResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// Moving the archive or switching between source_path and
+		// content_base64 does not import it again.
+		if plan.ArchiveSha256.Equal(state.ArchiveSha256) && plan.NamespaceID.Equal(state.NamespaceID) && plan.Policy.Equal(state.Policy) {
+			plan.ID = state.ID
+			plan.Succeeded = state.Succeeded
+			plan.Skipped = state.Skipped
+			plan.Failed = state.Failed
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// importArchive imports the archive configured by data and records the result
+// in data. The result of the prior import is kept when the archive, namespace
+// and policy are unchanged.
+func (r *ConfigurationImportResource) importArchive(ctx context.Context, data, prior *ConfigurationImportResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	archive, err := data.readArchive()
+	if err != nil {
+		diags.AddError(
+			"Unable to read configuration archive",
+			err.Error(),
+		)
+		return diags
+	}
+	namespaceId := data.NamespaceID.ValueString()
+	sum := sha256.Sum256(archive)
+	data.ArchiveSha256 = types.StringValue(hex.EncodeToString(sum[:]))
+	data.ID = types.StringValue(fmt.Sprintf("%s:%s", namespaceId, data.ArchiveSha256.ValueString()))
+	if prior != nil && data.ArchiveSha256.Equal(prior.ArchiveSha256) && data.NamespaceID.Equal(prior.NamespaceID) && data.Policy.Equal(prior.Policy) {
+		data.Succeeded = prior.Succeeded
+		data.Skipped = prior.Skipped
+		data.Failed = prior.Failed
+		return diags
+	}
+
+	configs, err := ParseConfigurationArchive(archive)
+	if err != nil {
+		diags.AddError(
+			"Unable to parse configuration archive",
+			err.Error(),
+		)
+		return diags
+	}
+
+	result, d := publishConfigurations(ctx, r.client, r.api, r.cipher, namespaceId, data.Policy.ValueString(), "import", configs)
+	diags.Append(d...)
+	diags.Append(result.SetLists(ctx, &data.Succeeded, &data.Skipped, &data.Failed)...)
+	return diags
+}
+
+func (r *ConfigurationImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConfigurationImportResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.importArchive(ctx, &data, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConfigurationImportResourceModel
+
+	// The import is a one-off operation, the state records its result.
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConfigurationImportResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ModifyPlan keeps the result of the previous import when the archive,
+	// namespace and policy are unchanged, an archive which is only read now
+	// is compared with the prior state.
+	if data.Succeeded.IsUnknown() {
+		var state ConfigurationImportResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.importArchive(ctx, &data, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConfigurationImportResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The imported configurations are left in place.
+	tflog.Debug(ctx, "removed configuration import from state", map[string]any{"id": data.ID.ValueString()})
+}
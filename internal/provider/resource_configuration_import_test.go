@@ -0,0 +1,175 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/joelee2012/go-nacos"
+)
+
+func testAccConfigurationImportConfig(sourcePath, policy string) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration_import" "test" {
+  source_path = %q
+  policy      = %q
+}
+`, sourcePath, policy)
+}
+
+func TestAccConfigurationImportResource(t *testing.T) {
+	resourceName := "nacos_configuration_import.test"
+	group := "import-group"
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: "import-test-b.yaml", Group: group, Content: "b: 0", Type: "yaml"})
+	t.Cleanup(func() {
+		if testClient != nil {
+			_ = testClient.DeleteConfig(context.Background(), &nacos.DeleteCfgOpts{DataID: "import-test-a.yaml", Group: group})
+		}
+	})
+
+	archive, err := BuildConfigurationArchive([]*nacos.Configuration{
+		{DataID: "import-test-a.yaml", Group: group, Content: "a: 1", Type: "yaml"},
+		{DataID: "import-test-b.yaml", Group: group, Content: "b: 1", Type: "yaml"},
+	}, ExportMetadataV2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sourcePath := filepath.Join(t.TempDir(), "import.zip")
+	if err := os.WriteFile(sourcePath, archive, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The existing configuration aborts the import.
+			{
+				Config: testAccConfigurationImportConfig(sourcePath, "ABORT"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("succeeded"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact(":import-group:import-test-a.yaml")}),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("failed"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact(":import-group:import-test-b.yaml")}),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("skipped"),
+						knownvalue.ListSizeExact(0),
+					),
+				},
+			},
+			// The unchanged archive is not imported again.
+			{
+				Config: testAccConfigurationImportConfig(sourcePath, "ABORT"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: testAccConfigurationImportConfig(sourcePath, "OVERWRITE"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("succeeded"),
+						knownvalue.ListSizeExact(2),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("failed"),
+						knownvalue.ListSizeExact(0),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccConfigurationImportResource_writtenDuringApply(t *testing.T) {
+	resourceName := "nacos_configuration_import.test"
+	group := "import-apply-group"
+	t.Cleanup(func() {
+		if testClient != nil {
+			_ = testClient.DeleteConfig(context.Background(), &nacos.DeleteCfgOpts{DataID: "import-apply-test.yaml", Group: group})
+		}
+	})
+
+	archive, err := BuildConfigurationArchive([]*nacos.Configuration{
+		{DataID: "import-apply-test.yaml", Group: group, Content: "a: 1", Type: "yaml"},
+	}, ExportMetadataV2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	buildPath := filepath.Join(dir, "build.zip")
+	if err := os.WriteFile(buildPath, archive, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// The archive does not exist at plan time, it is copied during apply.
+	sourcePath := filepath.Join(dir, "import.zip")
+	config := fmt.Sprintf(`
+resource "terraform_data" "archive" {
+  provisioner "local-exec" {
+    command = "cp %s %s"
+  }
+}
+
+resource "nacos_configuration_import" "test" {
+  source_path = %q
+  depends_on  = [terraform_data.archive]
+}
+`, buildPath, sourcePath, sourcePath)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// terraform_data was added in Terraform 1.4.
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_4_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("archive_sha256")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("succeeded"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact(":import-apply-group:import-apply-test.yaml")}),
+					),
+				},
+			},
+			// The archive exists now and is unchanged.
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
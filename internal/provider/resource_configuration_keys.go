@@ -0,0 +1,377 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+)
+
+// configurationKeysRetries is how many times a read-modify-write is retried
+// when the configuration is modified concurrently.
+const configurationKeysRetries = 3
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationKeysResource{}
+
+func NewConfigurationKeysResource() resource.Resource {
+	return &ConfigurationKeysResource{}
+}
+
+// ConfigurationKeysResource defines the resource implementation.
+type ConfigurationKeysResource struct {
+	client *nacos.Client
+	api    *NacosAPI
+	cipher *ConfigurationCipher
+}
+
+// ConfigurationKeysResourceModel describes the resource data model.
+type ConfigurationKeysResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	DataID      types.String `tfsdk:"data_id"`
+	Group       types.String `tfsdk:"group"`
+	NamespaceID types.String `tfsdk:"namespace_id"`
+	Keys        types.Map    `tfsdk:"keys"`
+}
+
+func (r *ConfigurationKeysResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_keys"
+}
+
+func (r *ConfigurationKeysResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a subset of the keys of an existing `properties`, `yaml` or `json` configuration, which is shared with others. " +
+			"The configuration is updated by read-modify-write with md5 compare-and-swap, other keys and comments are left untouched, changed values of existing keys are replaced in place, other changes re-encode `yaml` and `json` content with its indent and drop blank lines. " +
+			"Destroying the resource removes only the managed keys.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource. In the format of `<namespace_id>:<group>:<data_id>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"data_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration data id, the configuration must exist.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Configuration group, default is `DEFAULT_GROUP`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("DEFAULT_GROUP"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration namespace id, default is empty string which means public namespace.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"keys": schema.MapAttribute{
+				MarkdownDescription: "Managed keys and their values. For `properties` configurations keys are property keys and values are plain strings. " +
+					"For `yaml` and `json` configurations keys are dotted paths of object keys, e.g. `server.port`, and values are JSON encoded, " +
+					"use `jsonencode()` to set them. The format is the configuration type, or derived from the data id extension if the type is none of them.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConfigurationKeysResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.api = providerData.API
+	r.cipher = providerData.Cipher
+}
+
+// contentTypeOf returns the type used to edit the configuration content.
+func contentTypeOf(cfg *nacos.Configuration) string {
+	switch cfg.Type {
+	case "json", "yaml", "properties":
+		return cfg.Type
+	}
+	return configurationTypeOf(cfg.DataID)
+}
+
+// edit sets and removes keys of the configuration by read-modify-write, it
+// is retried when the configuration is modified between read and write.
+func (r *ConfigurationKeysResource) edit(ctx context.Context, data *ConfigurationKeysResourceModel, set map[string]string, remove []string, mustExist bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	getOpts := &nacos.GetCfgOpts{
+		DataID:      data.DataID.ValueString(),
+		Group:       data.Group.ValueString(),
+		NamespaceID: data.NamespaceID.ValueString(),
+	}
+	for attempt := 1; ; attempt++ {
+		config, err := r.client.GetConfig(ctx, getOpts)
+		if err != nil {
+			if IsNotFoundError(err) && !mustExist {
+				return diags
+			}
+			if IsNotFoundError(err) {
+				diags.AddError(
+					"Configuration not found",
+					fmt.Sprintf("The configuration %q in group %q does not exist, nacos_configuration_keys only manages keys of an existing configuration.", getOpts.DataID, getOpts.Group),
+				)
+				return diags
+			}
+			diags.AddError(
+				"Unable to read configuration",
+				err.Error(),
+			)
+			return diags
+		}
+		content, err := r.cipher.DecryptContent(ctx, config.DataID, config.Content, config.EncryptedDataKey)
+		if err != nil {
+			diags.AddError(
+				"Unable to decrypt configuration",
+				err.Error(),
+			)
+			return diags
+		}
+		edited, err := EditContent(contentTypeOf(config), content, set, remove)
+		if err != nil {
+			diags.AddError(
+				"Unable to edit configuration",
+				fmt.Sprintf("Unable to edit configuration %q in group %q: %s", getOpts.DataID, getOpts.Group, err.Error()),
+			)
+			return diags
+		}
+		if edited == content {
+			return diags
+		}
+		opts := &PublishCfgOpts{
+			DataID:      getOpts.DataID,
+			Group:       getOpts.Group,
+			NamespaceID: getOpts.NamespaceID,
+			Content:     edited,
+			Type:        config.Type,
+			Application: config.Application,
+			Description: config.Description,
+			Tags:        config.Tags,
+			CasMd5:      config.Md5,
+		}
+		if err := r.cipher.EncryptConfig(ctx, opts); err != nil {
+			diags.AddError(
+				"Unable to encrypt configuration",
+				err.Error(),
+			)
+			return diags
+		}
+		tflog.Debug(ctx, "editing configuration keys", map[string]any{
+			"namespace_id": opts.NamespaceID,
+			"group":        opts.Group,
+			"data_id":      opts.DataID,
+			"set":          sortedKeys(set),
+			"remove":       remove,
+			"attempt":      attempt,
+		})
+		err = r.api.PublishConfig(ctx, opts)
+		if err == nil {
+			return diags
+		}
+		current, getErr := r.client.GetConfig(ctx, getOpts)
+		if attempt < configurationKeysRetries && getErr == nil && current.Md5 != opts.CasMd5 {
+			continue
+		}
+		diags.AddError(
+			"Unable to update configuration",
+			err.Error(),
+		)
+		return diags
+	}
+}
+
+// readKeys returns the values of the owned keys found in the configuration,
+// nil when the configuration does not exist. Values equal to the owned values
+// are returned as owned.
+func (r *ConfigurationKeysResource) readKeys(ctx context.Context, data *ConfigurationKeysResourceModel, owned map[string]string) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	config, err := r.client.GetConfig(ctx, &nacos.GetCfgOpts{
+		DataID:      data.DataID.ValueString(),
+		Group:       data.Group.ValueString(),
+		NamespaceID: data.NamespaceID.ValueString(),
+	})
+	if err != nil {
+		if IsNotFoundError(err) {
+			return nil, diags
+		}
+		diags.AddError(
+			"Unable to read configuration",
+			err.Error(),
+		)
+		return nil, diags
+	}
+	if config.Content, err = r.cipher.DecryptContent(ctx, config.DataID, config.Content, config.EncryptedDataKey); err != nil {
+		diags.AddError(
+			"Unable to decrypt configuration",
+			err.Error(),
+		)
+		return nil, diags
+	}
+	contentType := contentTypeOf(config)
+	values, err := GetContentKeys(contentType, config.Content, sortedKeys(owned))
+	if err != nil {
+		diags.AddError(
+			"Unable to parse configuration",
+			fmt.Sprintf("Unable to parse configuration %q in group %q: %s", config.DataID, config.GetGroup(), err.Error()),
+		)
+		return nil, diags
+	}
+	NormalizeContentKeys(contentType, values, owned)
+	return values, diags
+}
+
+func (r *ConfigurationKeysResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConfigurationKeysResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set := make(map[string]string)
+	resp.Diagnostics.Append(data.Keys.ElementsAs(ctx, &set, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.edit(ctx, &data, set, nil, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = types.StringValue(BuildThreePartID(data.NamespaceID.ValueString(), data.Group.ValueString(), data.DataID.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationKeysResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConfigurationKeysResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owned := make(map[string]string)
+	resp.Diagnostics.Append(data.Keys.ElementsAs(ctx, &owned, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	values, diags := r.readKeys(ctx, &data, owned)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if values == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	// Keys removed outside of Terraform are dropped, so they are planned to
+	// be set again.
+	keys, diags := types.MapValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Keys = keys
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationKeysResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ConfigurationKeysResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set, owned := make(map[string]string), make(map[string]string)
+	resp.Diagnostics.Append(data.Keys.ElementsAs(ctx, &set, false)...)
+	resp.Diagnostics.Append(state.Keys.ElementsAs(ctx, &owned, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var remove []string
+	for _, key := range sortedKeys(owned) {
+		if _, ok := set[key]; !ok {
+			remove = append(remove, key)
+		}
+	}
+	resp.Diagnostics.Append(r.edit(ctx, &data, set, remove, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationKeysResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConfigurationKeysResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owned := make(map[string]string)
+	resp.Diagnostics.Append(data.Keys.ElementsAs(ctx, &owned, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Only the managed keys are removed, the configuration itself is kept.
+	resp.Diagnostics.Append(r.edit(ctx, &data, nil, sortedKeys(owned), false)...)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
+)
+
+func testAccConfigurationKeysConfig(keys string) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration_keys" "test" {
+  data_id = "keys-test.properties"
+  keys = {
+    %s
+  }
+}
+`, keys)
+}
+
+func testAccCheckConfigurationContent(dataId, want string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config, err := testClient.GetConfig(context.Background(), &nacos.GetCfgOpts{DataID: dataId, Group: "DEFAULT_GROUP"})
+		if err != nil {
+			return err
+		}
+		if config.Content != want {
+			return fmt.Errorf("expected content %q, got %q", want, config.Content)
+		}
+		return nil
+	}
+}
+
+func TestAccConfigurationKeysResource(t *testing.T) {
+	resourceName := "nacos_configuration_keys.test"
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{
+		DataID:  "keys-test.properties",
+		Group:   "DEFAULT_GROUP",
+		Content: "# shared\nother.key=other\nowned.a=1\n",
+		Type:    "properties",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckConfigurationContent("keys-test.properties", "# shared\nother.key=other\n"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigurationKeysConfig(`"owned.a" = "2"
+    "owned.b" = "new"`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(":DEFAULT_GROUP:keys-test.properties"),
+					),
+				},
+				Check: testAccCheckConfigurationContent("keys-test.properties", "# shared\nother.key=other\nowned.a=2\nowned.b=new\n"),
+			},
+			{
+				Config: testAccConfigurationKeysConfig(`"owned.b" = "changed"`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("keys"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"owned.b": knownvalue.StringExact("changed"),
+						}),
+					),
+				},
+				Check: testAccCheckConfigurationContent("keys-test.properties", "# shared\nother.key=other\nowned.b=changed\n"),
+			},
+		},
+	})
+}
+
+func TestAccConfigurationKeysResource_json(t *testing.T) {
+	resourceName := "nacos_configuration_keys.test"
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{
+		DataID:  "keys-test.json",
+		Group:   "DEFAULT_GROUP",
+		Content: `{"server": {"port": 8080}}`,
+		Type:    "json",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Values which are not written as they are read back do not
+			// show a difference after apply.
+			{
+				Config: `
+resource "nacos_configuration_keys" "test" {
+  data_id = "keys-test.json"
+  keys = {
+    "server.ratio" = "1.0"
+    "server.tls"   = "{ \"enabled\": true }"
+  }
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("keys"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"server.ratio": knownvalue.StringExact("1.0"),
+							"server.tls":   knownvalue.StringExact(`{ "enabled": true }`),
+						}),
+					),
+				},
+				Check: testAccCheckConfigurationContent("keys-test.json", "{\n  \"server\": {\n    \"port\": 8080,\n    \"ratio\": 1,\n    \"tls\": {\n      \"enabled\": true\n    }\n  }\n}\n"),
+			},
+		},
+	})
+}
@@ -0,0 +1,379 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+)
+
+// rollbackTagPrefix is the prefix of the tag recording the revision a
+// configuration was rolled back to.
+const rollbackTagPrefix = "rollback-"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationRollbackResource{}
+var _ resource.ResourceWithImportState = &ConfigurationRollbackResource{}
+
+func NewConfigurationRollbackResource() resource.Resource {
+	return &ConfigurationRollbackResource{}
+}
+
+// ConfigurationRollbackResource defines the resource implementation.
+type ConfigurationRollbackResource struct {
+	client *nacos.Client
+	api    *NacosAPI
+}
+
+// ConfigurationRollbackResourceModel describes the resource data model.
+type ConfigurationRollbackResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	ConfigurationID types.String `tfsdk:"configuration_id"`
+	Nid             types.Int64  `tfsdk:"nid"`
+	PreviousMd5     types.String `tfsdk:"previous_md5"`
+	Md5             types.String `tfsdk:"md5"`
+	SrcUser         types.String `tfsdk:"src_user"`
+	ModifyTime      types.Int64  `tfsdk:"modify_time"`
+}
+
+// RollbackTags returns the configuration tags with the tag recording the
+// rollback to revision nid, replacing the tag of any earlier rollback.
+func RollbackTags(tags string, nid int64) string {
+	var result []string
+	for _, tag := range strings.Split(tags, ",") {
+		if tag != "" && !strings.HasPrefix(tag, rollbackTagPrefix) {
+			result = append(result, tag)
+		}
+	}
+	result = append(result, fmt.Sprintf("%s%d", rollbackTagPrefix, nid))
+	return strings.Join(result, ",")
+}
+
+func BuildRollbackID(namespaceID, group, dataID string, nid int64) string {
+	return BuildFourPartID(namespaceID, group, dataID, strconv.FormatInt(nid, 10))
+}
+
+func ParseRollbackID(id string) (namespaceID, group, dataID string, nid int64, err error) {
+	namespaceID, group, dataID, name, err := parseFourPartID(id, "nid")
+	if err != nil {
+		return "", "", "", 0, err
+	}
+	nid, err = strconv.ParseInt(name, 10, 64)
+	if err != nil {
+		return "", "", "", 0, fmt.Errorf("unexpected ID format (%q). expected <namespace_id>:<group>:<data_id>:<nid> with a numeric nid", id)
+	}
+	return namespaceID, group, dataID, nid, nil
+}
+
+func (r *ConfigurationRollbackResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_rollback"
+}
+
+func (r *ConfigurationRollbackResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Rolls a configuration back to a revision of its history. " +
+			"The content of the revision is published again and the configuration is tagged with `rollback-<nid>`, destroying this resource does not undo the rollback. " +
+			"Do not roll back a configuration which is managed by a `nacos_configuration` resource, its next apply overwrites the rollback or fails because the configuration changed outside of Terraform. Roll back such a configuration by changing its `content` instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource. In the format of `<namespace_id>:<group>:<data_id>:<nid>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"configuration_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the configuration to roll back, in the format of `<namespace_id>:<group>:<data_id>`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"nid": schema.Int64Attribute{
+				MarkdownDescription: "ID of the revision to roll back to, see the `nacos_configuration_history` data source. " +
+					"Pin the nid instead of selecting the newest revisions, the rollback writes a new revision which changes such a selection and replaces this resource, rolling back again, on every apply.",
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"previous_md5": schema.StringAttribute{
+				MarkdownDescription: "Configuration md5 before the rollback, empty if the configuration did not exist or the rollback was imported.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"md5": schema.StringAttribute{
+				MarkdownDescription: "Configuration md5 after the rollback.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"src_user": schema.StringAttribute{
+				MarkdownDescription: "User who made the revision.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modify_time": schema.Int64Attribute{
+				MarkdownDescription: "Configuration modify time after the rollback.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConfigurationRollbackResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.api = providerData.API
+}
+
+func (r *ConfigurationRollbackResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConfigurationRollbackResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespaceId, group, dataId, err := ParseThreePartID(data.ConfigurationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("configuration_id"),
+			"Unable to parse configuration id",
+			err.Error(),
+		)
+		return
+	}
+	getOpts := &nacos.GetCfgOpts{
+		DataID:      dataId,
+		Group:       group,
+		NamespaceID: namespaceId,
+	}
+	nid := data.Nid.ValueInt64()
+	tflog.Debug(ctx, "rolling back configuration", map[string]any{
+		"namespace_id": namespaceId,
+		"group":        group,
+		"data_id":      dataId,
+		"nid":          nid,
+	})
+
+	revision, err := r.api.GetHistory(ctx, &GetHistoryOpts{
+		ID:          nid,
+		DataID:      dataId,
+		Group:       group,
+		NamespaceID: namespaceId,
+	})
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("nid"),
+			"Unable to read configuration revision",
+			err.Error(),
+		)
+		return
+	}
+
+	// Keep the metadata of the current configuration, a configuration which
+	// has been deleted is restored with the metadata of the revision.
+	opts := &PublishCfgOpts{
+		DataID:           dataId,
+		Group:            group,
+		NamespaceID:      namespaceId,
+		Content:          revision.Content,
+		EncryptedDataKey: revision.EncryptedDataKey,
+		Application:      revision.AppName,
+		Tags:             RollbackTags("", nid),
+	}
+	current, err := r.client.GetConfig(ctx, getOpts)
+	if err != nil && !IsNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Unable to read configuration",
+			err.Error(),
+		)
+		return
+	}
+	data.PreviousMd5 = types.StringValue("")
+	if err == nil {
+		data.PreviousMd5 = types.StringValue(current.Md5)
+		opts.Type = current.Type
+		opts.Application = current.Application
+		opts.Description = current.Description
+		opts.Tags = RollbackTags(current.Tags, nid)
+		opts.CasMd5 = current.Md5
+	}
+
+	if err := r.api.PublishConfig(ctx, opts); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to roll back configuration",
+			err.Error(),
+		)
+		return
+	}
+	config, err := r.client.GetConfig(ctx, getOpts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read configuration after rolling back",
+			err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(BuildRollbackID(namespaceId, group, dataId, nid))
+	data.Md5 = types.StringValue(config.Md5)
+	data.SrcUser = types.StringValue(revision.SrcUser)
+	data.ModifyTime = types.Int64Value(config.ModifyTime)
+
+	tflog.Debug(ctx, "rolled back configuration", map[string]any{
+		"namespace_id": namespaceId,
+		"group":        group,
+		"data_id":      dataId,
+		"nid":          nid,
+	})
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationRollbackResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConfigurationRollbackResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespaceId, group, dataId, err := ParseThreePartID(data.ConfigurationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse configuration id",
+			err.Error(),
+		)
+		return
+	}
+	// The rollback is a one-off operation, it is only forgotten when the
+	// configuration no longer exists.
+	config, err := r.client.GetConfig(ctx, &nacos.GetCfgOpts{
+		DataID:      dataId,
+		Group:       group,
+		NamespaceID: namespaceId,
+	})
+	if err != nil {
+		if IsNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to read configuration",
+			err.Error(),
+		)
+		return
+	}
+
+	// An imported rollback only knows its id, the configuration before the
+	// rollback is unknown.
+	if data.Md5.IsNull() {
+		revision, err := r.api.GetHistory(ctx, &GetHistoryOpts{
+			ID:          data.Nid.ValueInt64(),
+			DataID:      dataId,
+			Group:       group,
+			NamespaceID: namespaceId,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read configuration revision",
+				err.Error(),
+			)
+			return
+		}
+		data.PreviousMd5 = types.StringValue("")
+		data.Md5 = types.StringValue(config.Md5)
+		data.SrcUser = types.StringValue(revision.SrcUser)
+		data.ModifyTime = types.Int64Value(config.ModifyTime)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationRollbackResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConfigurationRollbackResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to publish here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationRollbackResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConfigurationRollbackResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A rollback cannot be undone, the configuration is left as it is.
+	tflog.Debug(ctx, "removed configuration rollback from state", map[string]any{"id": data.ID.ValueString()})
+}
+
+func (r *ConfigurationRollbackResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	namespaceId, group, dataId, nid, err := ParseRollbackID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse configuration rollback id",
+			err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("configuration_id"), BuildThreePartID(namespaceId, group, dataId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("nid"), nid)...)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
+)
+
+func TestRollbackTags(t *testing.T) {
+	cases := map[string]string{
+		"":                    "rollback-7",
+		"team-a":              "team-a,rollback-7",
+		"team-a,rollback-3,b": "team-a,b,rollback-7",
+	}
+	for tags, expected := range cases {
+		if got := RollbackTags(tags, 7); got != expected {
+			t.Errorf("RollbackTags(%q) = %q, expected %q", tags, got, expected)
+		}
+	}
+}
+
+func TestParseRollbackID(t *testing.T) {
+	namespaceId, group, dataId, nid, err := ParseRollbackID(BuildRollbackID("ns", "DEFAULT_GROUP", "app.properties", 42))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if namespaceId != "ns" || group != "DEFAULT_GROUP" || dataId != "app.properties" || nid != 42 {
+		t.Errorf("unexpected parts %q %q %q %d", namespaceId, group, dataId, nid)
+	}
+	for _, id := range []string{"ns:DEFAULT_GROUP:app.properties", "ns:DEFAULT_GROUP:app.properties:", "ns:DEFAULT_GROUP:app.properties:latest"} {
+		if _, _, _, _, err := ParseRollbackID(id); err == nil {
+			t.Errorf("expected error parsing %q", id)
+		}
+	}
+}
+
+func TestAccConfigurationRollbackResource(t *testing.T) {
+	dataId := "test-rollback-data-id"
+	group := "test-group"
+	opts := &nacos.CreateCfgOpts{DataID: dataId, Group: group, Content: "version=1"}
+	setupTestConfiguration(t, opts)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					update := *opts
+					update.Content = "version=2"
+					if err := testClient.CreateConfig(context.Background(), &update); err != nil {
+						t.Fatalf("Error updating configuration: %s", err)
+					}
+				},
+				// The update revision holds the content before the update.
+				Config: fmt.Sprintf(`
+data "nacos_configuration_history" "test" {
+  data_id = "%s"
+  group   = "%s"
+  op_type = "U"
+  limit   = 1
+}
+
+resource "nacos_configuration_rollback" "test" {
+  configuration_id = data.nacos_configuration_history.test.id
+  nid              = data.nacos_configuration_history.test.revisions[0].nid
+}
+
+data "nacos_configuration" "test" {
+  data_id    = "%s"
+  group      = "%s"
+  depends_on = [nacos_configuration_rollback.test]
+}
+`, dataId, group, dataId, group),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.nacos_configuration.test",
+						tfjsonpath.New("content"),
+						knownvalue.StringExact("version=1"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.nacos_configuration.test",
+						tfjsonpath.New("tags"),
+						knownvalue.SetExact([]knownvalue.Check{knownvalue.StringRegexp(regexp.MustCompile(`^rollback-\d+$`))}),
+					),
+				},
+			},
+			{
+				ResourceName:            "nacos_configuration_rollback.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"previous_md5"},
+			},
+		},
+	})
+}
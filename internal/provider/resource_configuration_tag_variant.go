@@ -0,0 +1,337 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationTagVariantResource{}
+var _ resource.ResourceWithImportState = &ConfigurationTagVariantResource{}
+var _ resource.ResourceWithModifyPlan = &ConfigurationTagVariantResource{}
+
+func NewConfigurationTagVariantResource() resource.Resource {
+	return &ConfigurationTagVariantResource{}
+}
+
+// ConfigurationTagVariantResource defines the resource implementation.
+type ConfigurationTagVariantResource struct {
+	client *nacos.Client
+	api    *NacosAPI
+	cipher *ConfigurationCipher
+}
+
+// ConfigurationTagVariantResourceModel describes the resource data model.
+type ConfigurationTagVariantResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	ConfigurationVariantModel
+	Tag types.String `tfsdk:"tag"`
+}
+
+func (c *ConfigurationTagVariantResourceModel) SetFromConfiguration(cfg *nacos.Configuration, tag string) {
+	c.ConfigurationVariantModel.SetFromConfiguration(cfg)
+	c.Tag = types.StringValue(tag)
+}
+
+func ParseTagVariantID(id string) (namespaceID, group, dataID, tag string, err error) {
+	return parseFourPartID(id, "tag")
+}
+
+func (r *ConfigurationTagVariantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_tag_variant"
+}
+
+func (r *ConfigurationTagVariantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Tag variant of a configuration, the content is delivered to the clients requesting the configuration with the `tag`. " +
+			"Unlike `tags` of `nacos_configuration`, which are metadata, each tag variant holds its own content. " +
+			"Requires Nacos 2.x (api_version v1), use a `tag` rule of `nacos_configuration_gray` on Nacos 3.x.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource. In the format of `<namespace_id>:<group>:<data_id>:<tag>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"data_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration data id.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Configuration group, default is `DEFAULT_GROUP`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("DEFAULT_GROUP"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration namespace id, default is empty string which means public namespace.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tag": schema.StringAttribute{
+				MarkdownDescription: "Tag selecting the variant, e.g. a region name.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Content of the tag variant.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Configuration type, default is `text`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("text"),
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"text", "json", "xml", "yaml", "html", "properties"}...),
+				},
+			},
+			"md5": schema.StringAttribute{
+				MarkdownDescription: "Md5 of the tag variant content.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ConfigurationTagVariantResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.api = providerData.API
+	r.cipher = providerData.Cipher
+}
+
+func (r *ConfigurationTagVariantResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy, or before the provider has been configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	if r.client.APIVersion == "v3" {
+		resp.Diagnostics.AddError(
+			"Unsupported Nacos API version",
+			"nacos_configuration_tag_variant requires Nacos 2.x (api_version v1), Nacos 3.x does not serve tag variants over the HTTP API. Use a tag rule of nacos_configuration_gray instead.",
+		)
+	}
+}
+
+// publish publishes the tag variant and reads it back into data.
+func (r *ConfigurationTagVariantResource) publish(ctx context.Context, data *ConfigurationTagVariantResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	opts := &PublishCfgOpts{
+		DataID:      data.DataID.ValueString(),
+		Group:       data.Group.ValueString(),
+		NamespaceID: data.NamespaceID.ValueString(),
+		Content:     data.Content.ValueString(),
+		Type:        data.Type.ValueString(),
+		Tag:         data.Tag.ValueString(),
+	}
+	if err := r.cipher.EncryptConfig(ctx, opts); err != nil {
+		diags.AddAttributeError(
+			path.Root("data_id"),
+			"Unable to encrypt configuration",
+			err.Error(),
+		)
+		return diags
+	}
+	tflog.Debug(ctx, "publishing configuration tag variant", map[string]any{
+		"namespace_id": opts.NamespaceID,
+		"group":        opts.Group,
+		"data_id":      opts.DataID,
+		"tag":          opts.Tag,
+	})
+	if err := r.api.PublishConfig(ctx, opts); err != nil {
+		diags.AddError(
+			"Unable to publish configuration tag variant",
+			err.Error(),
+		)
+		return diags
+	}
+	config, err := r.api.GetTagConfig(ctx, &TagCfgOpts{
+		DataID:      opts.DataID,
+		Group:       opts.Group,
+		NamespaceID: opts.NamespaceID,
+		Tag:         opts.Tag,
+	})
+	if err != nil {
+		diags.AddError(
+			"Unable to read configuration tag variant after publishing",
+			err.Error(),
+		)
+		return diags
+	}
+	data.ID = types.StringValue(BuildFourPartID(opts.NamespaceID, opts.Group, opts.DataID, opts.Tag))
+	data.Md5 = types.StringValue(config.Md5)
+	return diags
+}
+
+func (r *ConfigurationTagVariantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConfigurationTagVariantResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.publish(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationTagVariantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConfigurationTagVariantResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespaceId, group, dataId, tag, err := ParseTagVariantID(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse configuration tag variant id",
+			err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, "read configuration tag variant", map[string]any{
+		"namespace_id": namespaceId,
+		"group":        group,
+		"data_id":      dataId,
+		"tag":          tag,
+	})
+
+	config, err := r.api.GetTagConfig(ctx, &TagCfgOpts{
+		NamespaceID: namespaceId,
+		Group:       group,
+		DataID:      dataId,
+		Tag:         tag,
+	})
+	if err != nil {
+		if IsNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to read configuration tag variant",
+			err.Error(),
+		)
+		return
+	}
+	if config.Content, err = r.cipher.DecryptContent(ctx, config.DataID, config.Content, config.EncryptedDataKey); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to decrypt configuration",
+			err.Error(),
+		)
+		return
+	}
+	data.SetFromConfiguration(config, tag)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationTagVariantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConfigurationTagVariantResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.publish(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationTagVariantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConfigurationTagVariantResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &TagCfgOpts{
+		DataID:      data.DataID.ValueString(),
+		Group:       data.Group.ValueString(),
+		NamespaceID: data.NamespaceID.ValueString(),
+		Tag:         data.Tag.ValueString(),
+	}
+	tflog.Debug(ctx, "deleting configuration tag variant", map[string]any{
+		"namespace_id": opts.NamespaceID,
+		"group":        opts.Group,
+		"data_id":      opts.DataID,
+		"tag":          opts.Tag,
+	})
+	if err := r.api.DeleteTagConfig(ctx, opts); err != nil && !IsNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Unable to delete configuration tag variant",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ConfigurationTagVariantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
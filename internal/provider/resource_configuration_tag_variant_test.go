@@ -0,0 +1,123 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
+)
+
+func testAccConfigurationTagVariantConfig(content string) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration" "test" {
+  data_id = "tag-variant-test.properties"
+  content = "region=default"
+  type    = "properties"
+}
+
+resource "nacos_configuration_tag_variant" "test" {
+  data_id = nacos_configuration.test.data_id
+  tag     = "eu-west"
+  content = "%s"
+  type    = "properties"
+}
+
+resource "nacos_configuration_tag_variant" "us" {
+  data_id = nacos_configuration.test.data_id
+  tag     = "us-east"
+  content = "region=us-east"
+  type    = "properties"
+}
+`, content)
+}
+
+// testAccCheckTagVariantContent checks the content a client requesting the
+// configuration with the tag receives, an empty tag requests the released
+// content.
+func testAccCheckTagVariantContent(dataId, tag, want string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var config *nacos.Configuration
+		var err error
+		if tag == "" {
+			config, err = testClient.GetConfig(context.Background(), &nacos.GetCfgOpts{DataID: dataId, Group: "DEFAULT_GROUP"})
+		} else {
+			config, err = testAPI.GetTagConfig(context.Background(), &TagCfgOpts{DataID: dataId, Group: "DEFAULT_GROUP", Tag: tag})
+		}
+		if err != nil {
+			return err
+		}
+		if config.Content != want {
+			return fmt.Errorf("expected content %q for tag %q, got %q", want, tag, config.Content)
+		}
+		return nil
+	}
+}
+
+func TestAccConfigurationTagVariantResource(t *testing.T) {
+	resourceName := "nacos_configuration_tag_variant.test"
+	skipV3 := func() (bool, error) { return testClient.APIVersion == "v3", nil }
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc:    func() (bool, error) { return testClient.APIVersion != "v3", nil },
+				Config:      testAccConfigurationTagVariantConfig("region=eu-west"),
+				ExpectError: regexp.MustCompile("requires Nacos 2.x"),
+			},
+			{
+				SkipFunc: skipV3,
+				Config:   testAccConfigurationTagVariantConfig("region=eu-west"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTagVariantContent("tag-variant-test.properties", "eu-west", "region=eu-west"),
+					testAccCheckTagVariantContent("tag-variant-test.properties", "us-east", "region=us-east"),
+					testAccCheckTagVariantContent("tag-variant-test.properties", "", "region=default"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(":DEFAULT_GROUP:tag-variant-test.properties:eu-west"),
+					),
+					statecheck.ExpectKnownValue(
+						"nacos_configuration.test",
+						tfjsonpath.New("content"),
+						knownvalue.StringExact("region=default"),
+					),
+				},
+			},
+			{
+				SkipFunc:          skipV3,
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				SkipFunc: skipV3,
+				Config:   testAccConfigurationTagVariantConfig("region=eu-west-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTagVariantContent("tag-variant-test.properties", "eu-west", "region=eu-west-1"),
+					testAccCheckTagVariantContent("tag-variant-test.properties", "us-east", "region=us-east"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content"),
+						knownvalue.StringExact("region=eu-west-1"),
+					),
+				},
+			},
+		},
+	})
+}
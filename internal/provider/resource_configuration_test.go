@@ -1,14 +1,23 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/joelee2012/go-nacos"
 )
 
 func testAccConfigurationSourceConfig(namespaceId, group, dataId, content, description string) string {
@@ -140,3 +149,530 @@ func TestAccConfigurationResource(t *testing.T) {
 		},
 	})
 }
+
+func testAccConfigurationContentSchemaConfig(dataId, content string) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration" "test" {
+  data_id = "%s"
+  type    = "yaml"
+  content = <<EOT
+%s
+EOT
+  content_schema = jsonencode({
+    type     = "object"
+    required = ["routes"]
+    properties = {
+      routes = {
+        type = "array"
+        items = {
+          type     = "object"
+          required = ["uri"]
+        }
+      }
+    }
+  })
+}
+`, dataId, content)
+}
+
+func TestAccConfigurationResource_contentSchema(t *testing.T) {
+	resourceName := "nacos_configuration.test"
+	dataId := "gateway-test.yaml"
+	content := `routes:
+  - uri: lb://orders`
+	invalidContent := `routes:
+  - id: orders`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccConfigurationContentSchemaConfig(dataId, invalidContent),
+				ExpectError: regexp.MustCompile(`At /routes/0: missing property 'uri'`),
+			},
+			{
+				Config: testAccConfigurationContentSchemaConfig(dataId, content),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("data_id"),
+						knownvalue.StringExact(dataId),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccConfigurationStructuredContentConfig(dataId, port string) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration" "test" {
+  data_id = "%s"
+  type    = "properties"
+  structured_content = {
+    server = {
+      port  = %s
+      hosts = ["a.example.com", "b.example.com"]
+    }
+    spring = {
+      application = { name = "orders" }
+    }
+  }
+}
+`, dataId, port)
+}
+
+func TestAccConfigurationResource_structuredContent(t *testing.T) {
+	resourceName := "nacos_configuration.test"
+	dataId := "structured-test.properties"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigurationStructuredContentConfig(dataId, "8080"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content"),
+						knownvalue.StringExact("server.hosts[0]=a.example.com\nserver.hosts[1]=b.example.com\nserver.port=8080\nspring.application.name=orders\n"),
+					),
+				},
+			},
+			{
+				Config: testAccConfigurationStructuredContentConfig(dataId, "9090"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content"),
+						knownvalue.StringExact("server.hosts[0]=a.example.com\nserver.hosts[1]=b.example.com\nserver.port=9090\nspring.application.name=orders\n"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccConfigurationSensitiveContentConfig(dataId, password string) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration" "test" {
+  data_id           = "%s"
+  type              = "properties"
+  sensitive_content = "db.password=%s"
+}
+`, dataId, password)
+}
+
+func TestAccConfigurationResource_sensitiveContent(t *testing.T) {
+	resourceName := "nacos_configuration.test"
+	dataId := "sensitive-test.properties"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigurationSensitiveContentConfig(dataId, "secret"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectSensitiveValue(
+						resourceName,
+						tfjsonpath.New("sensitive_content"),
+					),
+				},
+			},
+			{
+				Config: testAccConfigurationSensitiveContentConfig(dataId, "rotated"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("sensitive_content"),
+						knownvalue.StringExact("db.password=rotated"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccConfigurationContentNotInStateConfig(dataId, content string) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration" "test" {
+  data_id                = "%s"
+  content_wo             = "%s"
+  store_content_in_state = false
+}
+`, dataId, content)
+}
+
+func TestAccConfigurationResource_contentNotInState(t *testing.T) {
+	resourceName := "nacos_configuration.test"
+	dataId := "hash-only-test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// Write-only attributes are supported since Terraform 1.11
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigurationContentNotInStateConfig(dataId, "v1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content_sha256"),
+						knownvalue.StringExact(ContentSHA256("v1")),
+					),
+				},
+			},
+			{
+				Config: testAccConfigurationContentNotInStateConfig(dataId, "v2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content_sha256"),
+						knownvalue.StringExact(ContentSHA256("v2")),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccConfigurationForceOverwriteConfig(dataId, content string, forceOverwrite bool) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration" "test" {
+  data_id         = "%s"
+  content         = "%s"
+  type            = "properties"
+  force_overwrite = %t
+}
+`, dataId, content, forceOverwrite)
+}
+
+func TestAccConfigurationResource_forceOverwrite(t *testing.T) {
+	resourceName := "nacos_configuration.test"
+	dataId := "cas-test.properties"
+	// Simulate an edit made in the Nacos console, which is picked up by refresh.
+	editInConsole := func() {
+		err := testClient.CreateConfig(context.Background(), &nacos.CreateCfgOpts{
+			DataID:  dataId,
+			Group:   "DEFAULT_GROUP",
+			Content: "key=console",
+			Type:    "properties",
+		})
+		if err != nil {
+			t.Fatalf("Error updating configuration: %s", err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigurationForceOverwriteConfig(dataId, "key=one", false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("force_overwrite"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+			{
+				PreConfig: editInConsole,
+				Config:    testAccConfigurationForceOverwriteConfig(dataId, "key=two", false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content"),
+						knownvalue.StringExact("key=two"),
+					),
+				},
+			},
+			{
+				PreConfig: editInConsole,
+				Config:    testAccConfigurationForceOverwriteConfig(dataId, "key=three", true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content"),
+						knownvalue.StringExact("key=three"),
+					),
+				},
+			},
+		},
+	})
+}
+
+type testConfigurationGetter struct {
+	config *nacos.Configuration
+	err    error
+}
+
+func (g testConfigurationGetter) GetConfig(_ context.Context, _ *nacos.GetCfgOpts) (*nacos.Configuration, error) {
+	return g.config, g.err
+}
+
+// The acceptance test above edits the configuration before the plan, which
+// refresh picks up, so the rejected publish is covered here.
+func TestConfigurationCasMd5(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateState{}
+	if diags := SetPrivateMd5(ctx, private, "recorded"); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	data := ConfigurationResourceModel{ForceOverwrite: types.BoolValue(false)}
+	if md5, diags := data.CasMd5(ctx, private); diags.HasError() || md5 != "recorded" {
+		t.Errorf("unexpected cas md5 %q: %v", md5, diags)
+	}
+	data.ForceOverwrite = types.BoolValue(true)
+	if md5, diags := data.CasMd5(ctx, private); diags.HasError() || md5 != "" {
+		t.Errorf("expected no cas md5 with force_overwrite, got %q: %v", md5, diags)
+	}
+
+	cases := []struct {
+		name   string
+		casMd5 string
+		getter testConfigurationGetter
+		want   bool
+	}{
+		{"unconditional", "", testConfigurationGetter{config: &nacos.Configuration{Md5: "other"}}, false},
+		{"unchanged", "recorded", testConfigurationGetter{config: &nacos.Configuration{Md5: "recorded"}}, false},
+		{"changed", "recorded", testConfigurationGetter{config: &nacos.Configuration{Md5: "other"}}, true},
+		{"deleted", "recorded", testConfigurationGetter{err: nacos.ErrNotFound}, true},
+		{"unreadable", "recorded", testConfigurationGetter{err: errors.New("connection refused")}, false},
+	}
+	for _, c := range cases {
+		opts := &PublishCfgOpts{DataID: "app.properties", Group: "DEFAULT_GROUP", CasMd5: c.casMd5}
+		if got := changedOutside(ctx, c.getter, opts); got != c.want {
+			t.Errorf("%s: expected changedOutside %t, got %t", c.name, c.want, got)
+		}
+	}
+}
+
+func TestAccConfigurationResource_computedAttributes(t *testing.T) {
+	resourceName := "nacos_configuration.test"
+	dataId := "computed-test.properties"
+	md5Same := statecheck.CompareValue(compare.ValuesSame())
+	createTimeSame := statecheck.CompareValue(compare.ValuesSame())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigurationForceOverwriteConfig(dataId, "key=one", false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					md5Same.AddStateValue(resourceName, tfjsonpath.New("md5")),
+					createTimeSame.AddStateValue(resourceName, tfjsonpath.New("create_time")),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("md5"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+			// Changes which are not published keep md5 and modify_time
+			{
+				Config: testAccConfigurationForceOverwriteConfig(dataId, "key=one", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("md5"), knownvalue.NotNull()),
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("modify_time"), knownvalue.NotNull()),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					md5Same.AddStateValue(resourceName, tfjsonpath.New("md5")),
+				},
+			},
+			{
+				Config: testAccConfigurationForceOverwriteConfig(dataId, "key=two", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("md5")),
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("modify_time")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					createTimeSame.AddStateValue(resourceName, tfjsonpath.New("create_time")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccConfigurationResource_adoptExisting(t *testing.T) {
+	resourceName := "nacos_configuration.test"
+	dataId := "adopt-test.properties"
+	setupTestConfiguration(t, &nacos.CreateCfgOpts{DataID: dataId, Group: "DEFAULT_GROUP", Content: "key=console", Type: "properties"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccConfigurationForceOverwriteConfig(dataId, "key=terraform", false),
+				ExpectError: regexp.MustCompile("Configuration already exists"),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "nacos_configuration" "test" {
+  data_id        = "%s"
+  content        = "key=terraform"
+  type           = "properties"
+  adopt_existing = true
+}
+`, dataId),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("content"),
+						knownvalue.StringExact("key=terraform"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccConfigurationResource_retain(t *testing.T) {
+	resourceName := "nacos_configuration.test"
+	dataId := "retain-test.properties"
+	t.Cleanup(func() {
+		if testClient != nil {
+			_ = testClient.DeleteConfig(context.Background(), &nacos.DeleteCfgOpts{DataID: dataId, Group: "DEFAULT_GROUP"})
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			_, err := testClient.GetConfig(context.Background(), &nacos.GetCfgOpts{DataID: dataId, Group: "DEFAULT_GROUP"})
+			if err != nil {
+				return fmt.Errorf("expected configuration to be retained: %w", err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "nacos_configuration" "test" {
+  data_id         = "%s"
+  content         = "key=value"
+  type            = "properties"
+  deletion_policy = "RETAIN"
+}
+`, dataId),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("deletion_policy"),
+						knownvalue.StringExact("RETAIN"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccConfigurationWaitForPropagationConfig(dataId, content, timeout string) string {
+	return fmt.Sprintf(`
+resource "nacos_configuration" "test" {
+  data_id = "%s"
+  content = "%s"
+  type    = "properties"
+
+  wait_for_propagation {
+    timeout = "%s"
+  }
+}
+`, dataId, content, timeout)
+}
+
+func TestAccConfigurationResource_waitForPropagation(t *testing.T) {
+	resourceName := "nacos_configuration.test"
+	dataId := "propagation-test.properties"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccConfigurationWaitForPropagationConfig(dataId, "key=one", "soon"),
+				ExpectError: regexp.MustCompile("Invalid propagation timeout"),
+			},
+			{
+				Config: testAccConfigurationWaitForPropagationConfig(dataId, "key=one", "30s"),
+			},
+			// Without listeners the update completes immediately
+			{
+				Config: testAccConfigurationWaitForPropagationConfig(dataId, "key=two", "30s"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("wait_for_propagation").AtMapKey("timeout"),
+						knownvalue.StringExact("30s"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccConfigurationResource_preventDestroyIfListened(t *testing.T) {
+	resourceName := "nacos_configuration.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			_, err := testClient.GetConfig(context.Background(), &nacos.GetCfgOpts{DataID: "prevent-destroy-test.properties", Group: "DEFAULT_GROUP"})
+			if err == nil {
+				return fmt.Errorf("expected configuration without listeners to be deleted")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "nacos_configuration" "test" {
+  data_id                     = "prevent-destroy-test.properties"
+  content                     = "key=value"
+  type                        = "properties"
+  prevent_destroy_if_listened = true
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("force_destroy"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+		},
+	})
+}
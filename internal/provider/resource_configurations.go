@@ -0,0 +1,459 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joelee2012/go-nacos"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationsResource{}
+var _ resource.ResourceWithModifyPlan = &ConfigurationsResource{}
+
+func NewConfigurationsResource() resource.Resource {
+	return &ConfigurationsResource{}
+}
+
+// ConfigurationsResource defines the resource implementation.
+type ConfigurationsResource struct {
+	client *nacos.Client
+	api    *NacosAPI
+	cipher *ConfigurationCipher
+}
+
+// ConfigurationsResourceModel describes the resource data model.
+type ConfigurationsResourceModel struct {
+	ID          types.String                        `tfsdk:"id"`
+	NamespaceID types.String                        `tfsdk:"namespace_id"`
+	Group       types.String                        `tfsdk:"group"`
+	Items       map[string]*ConfigurationsItemModel `tfsdk:"items"`
+}
+
+// ConfigurationsItemModel describes a configuration of the
+// nacos_configurations resource, keyed by data id.
+type ConfigurationsItemModel struct {
+	Content     types.String `tfsdk:"content"`
+	Type        types.String `tfsdk:"type"`
+	Description types.String `tfsdk:"description"`
+	Tags        types.Set    `tfsdk:"tags"`
+	Md5         types.String `tfsdk:"md5"`
+}
+
+// SamePublished reports whether publishing the item would not change the
+// published configuration, unknown values never compare equal.
+func (i *ConfigurationsItemModel) SamePublished(other *ConfigurationsItemModel) bool {
+	return !i.Content.IsUnknown() && i.Content.Equal(other.Content) &&
+		!i.Type.IsUnknown() && i.Type.Equal(other.Type) &&
+		!i.Description.IsUnknown() && i.Description.Equal(other.Description) &&
+		!i.Tags.IsUnknown() && i.Tags.Equal(other.Tags)
+}
+
+// SetFromConfiguration refreshes the item from the published configuration
+// with the decrypted content. An empty description or empty tags on the server
+// keep an unset description or tags unset.
+func (i *ConfigurationsItemModel) SetFromConfiguration(ctx context.Context, cfg *nacos.Configuration, content string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	i.Content = types.StringValue(content)
+	i.Type = types.StringValue(cfg.Type)
+	i.Md5 = types.StringValue(cfg.Md5)
+	if cfg.Description != "" || !i.Description.IsNull() {
+		i.Description = types.StringValue(cfg.Description)
+	}
+	switch {
+	case cfg.Tags != "":
+		i.Tags, diags = types.SetValueFrom(ctx, types.StringType, strings.Split(cfg.Tags, ","))
+	case i.Tags.IsNull() || len(i.Tags.Elements()) == 0:
+		// Keep an empty set as configured.
+	default:
+		i.Tags = types.SetNull(types.StringType)
+	}
+	return diags
+}
+
+// sortedDataIDs returns the data ids of the items in a stable order.
+func sortedDataIDs(items map[string]*ConfigurationsItemModel) []string {
+	dataIDs := make([]string, 0, len(items))
+	for dataID := range items {
+		dataIDs = append(dataIDs, dataID)
+	}
+	sort.Strings(dataIDs)
+	return dataIDs
+}
+
+// contentMd5 returns the md5 Nacos computes for the content.
+func contentMd5(content string) string {
+	sum := md5.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *ConfigurationsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configurations"
+}
+
+func (r *ConfigurationsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages many configurations of a namespace and group as one unit. " +
+			"Only the items which changed are published or deleted, items which fail are retried on the next apply. " +
+			"Existing configurations with the same data id are overwritten.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this Terraform resource. In the format of `<namespace_id>:<group>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "Configuration namespace id, default is empty string which means public namespace.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Configuration group, default is `DEFAULT_GROUP`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("DEFAULT_GROUP"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"items": schema.MapNestedAttribute{
+				MarkdownDescription: "Configurations keyed by data id.",
+				Required:            true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							MarkdownDescription: "Configuration content.",
+							Required:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Configuration type, default is `text`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("text"),
+							Validators: []validator.String{
+								stringvalidator.OneOf([]string{"text", "json", "xml", "yaml", "html", "properties"}...),
+							},
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Configuration description.",
+							Optional:            true,
+						},
+						"tags": schema.SetAttribute{
+							MarkdownDescription: "Configuration tags.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"md5": schema.StringAttribute{
+							MarkdownDescription: "Md5 of the published content, empty when the item failed to publish.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ConfigurationsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*NacosProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.api = providerData.API
+	r.cipher = providerData.Cipher
+}
+
+func (r *ConfigurationsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to publish on create or destroy.
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+	var plan, state ConfigurationsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	pending, diags := GetPrivatePending(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	isPending := make(map[string]bool, len(pending))
+	for _, dataID := range pending {
+		isPending[dataID] = true
+	}
+
+	// Keep the md5 of unchanged items so that only the changed items show
+	// up in the plan, items which failed to publish are published again.
+	for dataID, item := range plan.Items {
+		prior, ok := state.Items[dataID]
+		if ok && !isPending[dataID] && item.SamePublished(prior) {
+			item.Md5 = prior.Md5
+		} else {
+			item.Md5 = types.StringUnknown()
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// apply publishes the items of plan with an unknown md5 and deletes the
+// given data ids. Failures are reported as warnings and returned, failed
+// items are stored with an empty md5.
+func (r *ConfigurationsResource) apply(ctx context.Context, plan *ConfigurationsResourceModel, deletes []string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	namespaceId, group := plan.NamespaceID.ValueString(), plan.Group.ValueString()
+	var failed, notPublished, notDeleted []string
+
+	for _, dataID := range sortedDataIDs(plan.Items) {
+		item := plan.Items[dataID]
+		if !item.Md5.IsUnknown() {
+			continue
+		}
+		opts := &PublishCfgOpts{
+			DataID:      dataID,
+			Group:       group,
+			NamespaceID: namespaceId,
+			Content:     item.Content.ValueString(),
+			Type:        item.Type.ValueString(),
+			Description: item.Description.ValueString(),
+		}
+		tags, d := JoinTags(ctx, item.Tags)
+		diags.Append(d...)
+		opts.Tags = tags
+		err := r.cipher.EncryptConfig(ctx, opts)
+		if err == nil {
+			tflog.Debug(ctx, "publishing configuration", map[string]any{
+				"namespace_id": namespaceId,
+				"group":        group,
+				"data_id":      dataID,
+			})
+			err = r.api.PublishConfig(ctx, opts)
+		}
+		if err != nil {
+			item.Md5 = types.StringValue("")
+			failed = append(failed, dataID)
+			notPublished = append(notPublished, fmt.Sprintf("%s: %s", dataID, err.Error()))
+			continue
+		}
+		item.Md5 = types.StringValue(contentMd5(opts.Content))
+	}
+
+	for _, dataID := range deletes {
+		tflog.Debug(ctx, "deleting configuration", map[string]any{
+			"namespace_id": namespaceId,
+			"group":        group,
+			"data_id":      dataID,
+		})
+		err := r.client.DeleteConfig(ctx, &nacos.DeleteCfgOpts{DataID: dataID, Group: group, NamespaceID: namespaceId})
+		if err != nil && !IsNotFoundError(err) {
+			failed = append(failed, dataID)
+			notDeleted = append(notDeleted, fmt.Sprintf("%s: %s", dataID, err.Error()))
+		}
+	}
+
+	if len(notPublished) > 0 {
+		diags.AddWarning(
+			"Configurations not published",
+			fmt.Sprintf("They are published again on the next apply.\n\n%s", strings.Join(notPublished, "\n")),
+		)
+	}
+	if len(notDeleted) > 0 {
+		diags.AddWarning(
+			"Configurations not deleted",
+			fmt.Sprintf("They are deleted again on the next apply.\n\n%s", strings.Join(notDeleted, "\n")),
+		)
+	}
+	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", namespaceId, group))
+	return failed, diags
+}
+
+func (r *ConfigurationsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConfigurationsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	failed, diags := r.apply(ctx, &data, nil)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(SetPrivatePending(ctx, resp.Private, failed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConfigurationsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	pending, diags := GetPrivatePending(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespaceId, group := data.NamespaceID.ValueString(), data.Group.ValueString()
+
+	// Pending data ids which are not managed any more failed to delete, they
+	// are brought back into state to be deleted again.
+	for _, dataID := range pending {
+		if _, ok := data.Items[dataID]; !ok {
+			if data.Items == nil {
+				data.Items = make(map[string]*ConfigurationsItemModel)
+			}
+			data.Items[dataID] = &ConfigurationsItemModel{Tags: types.SetNull(types.StringType)}
+		}
+	}
+	// The configuration list does not include the description and tags, so
+	// each item is read on its own.
+	for _, dataID := range sortedDataIDs(data.Items) {
+		cfg, err := r.client.GetConfig(ctx, &nacos.GetCfgOpts{DataID: dataID, Group: group, NamespaceID: namespaceId})
+		if err != nil {
+			if IsNotFoundError(err) {
+				delete(data.Items, dataID)
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Unable to read configuration",
+				fmt.Sprintf("Unable to read configuration %s: %s", BuildThreePartID(namespaceId, group, dataID), err.Error()),
+			)
+			return
+		}
+		content, err := r.cipher.DecryptContent(ctx, dataID, cfg.Content, cfg.EncryptedDataKey)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to decrypt configuration",
+				fmt.Sprintf("Unable to decrypt configuration %s: %s", BuildThreePartID(namespaceId, group, dataID), err.Error()),
+			)
+			return
+		}
+		resp.Diagnostics.Append(data.Items[dataID].SetFromConfiguration(ctx, cfg, content)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ConfigurationsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	pending, diags := GetPrivatePending(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var deletes []string
+	for _, dataID := range sortedDataIDs(state.Items) {
+		if _, ok := data.Items[dataID]; !ok {
+			deletes = append(deletes, dataID)
+		}
+	}
+	for _, dataID := range pending {
+		_, managed := data.Items[dataID]
+		_, known := state.Items[dataID]
+		if !managed && !known {
+			deletes = append(deletes, dataID)
+		}
+	}
+
+	failed, diags := r.apply(ctx, &data, deletes)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(SetPrivatePending(ctx, resp.Private, failed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConfigurationsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	pending, diags := GetPrivatePending(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespaceId, group := data.NamespaceID.ValueString(), data.Group.ValueString()
+	dataIDs := sortedDataIDs(data.Items)
+	for _, dataID := range pending {
+		if _, ok := data.Items[dataID]; !ok {
+			dataIDs = append(dataIDs, dataID)
+		}
+	}
+	for _, dataID := range dataIDs {
+		tflog.Debug(ctx, "deleting configuration", map[string]any{
+			"namespace_id": namespaceId,
+			"group":        group,
+			"data_id":      dataID,
+		})
+		err := r.client.DeleteConfig(ctx, &nacos.DeleteCfgOpts{DataID: dataID, Group: group, NamespaceID: namespaceId})
+		if err != nil && !IsNotFoundError(err) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("items").AtMapKey(dataID),
+				"Unable to delete configuration",
+				err.Error(),
+			)
+		}
+	}
+}
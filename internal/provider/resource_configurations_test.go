@@ -0,0 +1,169 @@
+// Copyright (c) Joe Lee
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
+)
+
+func TestConfigurationsItemSamePublished(t *testing.T) {
+	item := func(content string) *ConfigurationsItemModel {
+		return &ConfigurationsItemModel{
+			Content:     types.StringValue(content),
+			Type:        types.StringValue("text"),
+			Description: types.StringNull(),
+			Tags:        types.SetNull(types.StringType),
+		}
+	}
+	if !item("a").SamePublished(item("a")) {
+		t.Error("expected items with the same content to be the same")
+	}
+	if item("a").SamePublished(item("b")) {
+		t.Error("expected items with different content to differ")
+	}
+	unknown := item("a")
+	unknown.Content = types.StringUnknown()
+	if unknown.SamePublished(item("a")) {
+		t.Error("expected unknown content to differ")
+	}
+	if got := contentMd5(""); got != "d41d8cd98f00b204e9800998ecf8427e" {
+		t.Errorf("unexpected md5 %q", got)
+	}
+}
+
+func testAccConfigurationsResourceConfig(items string) string {
+	return fmt.Sprintf(`
+resource "nacos_configurations" "test" {
+  group = "bulk-group"
+  items = {
+%s
+  }
+}
+`, items)
+}
+
+func TestAccConfigurationsResource(t *testing.T) {
+	resourceName := "nacos_configurations.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			for _, dataId := range []string{"a.yaml", "b.yaml", "c.yaml", "d.yaml"} {
+				_, err := testClient.GetConfig(context.Background(), &nacos.GetCfgOpts{DataID: dataId, Group: "bulk-group"})
+				if err == nil {
+					return fmt.Errorf("configuration %s still exists", dataId)
+				}
+				if !IsNotFoundError(err) {
+					return err
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigurationsResourceConfig(`
+    "a.yaml" = { content = "a: 1", type = "yaml" }
+    "b.yaml" = { content = "b: 1", type = "yaml" }
+    "d.yaml" = { content = "d: 1", type = "yaml", tags = ["bulk"] }
+`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(":bulk-group"),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("items").AtMapKey("a.yaml").AtMapKey("md5"),
+						knownvalue.StringExact(contentMd5("a: 1")),
+					),
+				},
+			},
+			// Only the changed items are published.
+			{
+				Config: testAccConfigurationsResourceConfig(`
+    "a.yaml" = { content = "a: 2", type = "yaml" }
+    "c.yaml" = { content = "c: 1", type = "yaml" }
+    "d.yaml" = { content = "d: 1", type = "yaml", tags = ["bulk"] }
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("items").AtMapKey("a.yaml").AtMapKey("md5")),
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("items").AtMapKey("c.yaml").AtMapKey("md5")),
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("items").AtMapKey("d.yaml").AtMapKey("md5"), knownvalue.StringExact(contentMd5("d: 1"))),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("items"),
+						knownvalue.MapSizeExact(3),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("items").AtMapKey("a.yaml").AtMapKey("content"),
+						knownvalue.StringExact("a: 2"),
+					),
+				},
+			},
+			{
+				Config: testAccConfigurationsResourceConfig(`
+    "a.yaml" = { content = "a: 2", type = "yaml" }
+    "c.yaml" = { content = "c: 1", type = "yaml" }
+    "d.yaml" = { content = "d: 1", type = "yaml", tags = ["bulk"], description = "bulk d" }
+`),
+			},
+			// Refresh detects a description changed outside of Terraform.
+			{
+				PreConfig: func() {
+					err := testClient.CreateConfig(context.Background(), &nacos.CreateCfgOpts{
+						DataID:      "d.yaml",
+						Group:       "bulk-group",
+						Content:     "d: 1",
+						Type:        "yaml",
+						Description: "changed in console",
+						Tags:        "bulk,console",
+					})
+					if err != nil {
+						t.Fatalf("Error updating configuration: %s", err)
+					}
+				},
+				Config: testAccConfigurationsResourceConfig(`
+    "a.yaml" = { content = "a: 2", type = "yaml" }
+    "c.yaml" = { content = "c: 1", type = "yaml" }
+    "d.yaml" = { content = "d: 1", type = "yaml", tags = ["bulk"], description = "bulk d" }
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("items").AtMapKey("d.yaml").AtMapKey("md5")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("items").AtMapKey("d.yaml").AtMapKey("description"),
+						knownvalue.StringExact("bulk d"),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("items").AtMapKey("d.yaml").AtMapKey("tags"),
+						knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("bulk")}),
+					),
+				},
+			},
+		},
+	})
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/joelee2012/go-nacos"
@@ -27,14 +30,18 @@ func NewNamespaceResource() resource.Resource {
 // NamespaceResource defines the resource implementation.
 type NamespaceResource struct {
 	client *nacos.Client
+	// adoptExisting is the provider default of adopt_existing.
+	adoptExisting bool
 }
 
 // NamespaceResourceModel describes the resource data model.
 type NamespaceResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	NamespaceID types.String `tfsdk:"namespace_id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
+	ID             types.String `tfsdk:"id"`
+	NamespaceID    types.String `tfsdk:"namespace_id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	AdoptExisting  types.Bool   `tfsdk:"adopt_existing"`
+	DeletionPolicy types.String `tfsdk:"deletion_policy"`
 }
 
 func (n *NamespaceResourceModel) SetFromNamespace(ns *nacos.Namespace) {
@@ -42,6 +49,9 @@ func (n *NamespaceResourceModel) SetFromNamespace(ns *nacos.Namespace) {
 	n.NamespaceID = types.StringValue(ns.ID)
 	n.Description = types.StringValue(ns.Description)
 	n.Name = types.StringValue(ns.Name)
+	if n.DeletionPolicy.IsNull() {
+		n.DeletionPolicy = types.StringValue(DeletionPolicyDelete)
+	}
 }
 func (r *NamespaceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_namespace"
@@ -76,6 +86,19 @@ func (r *NamespaceResource) Schema(ctx context.Context, req resource.SchemaReque
 				MarkdownDescription: "Description of namespace.",
 				Optional:            true,
 			},
+			"deletion_policy": schema.StringAttribute{
+				MarkdownDescription: "What happens to the namespace when the resource is destroyed, `DELETE` (default) deletes it from Nacos and `RETAIN` only removes it from Terraform state.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(DeletionPolicyDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(DeletionPolicyDelete, DeletionPolicyRetain),
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over a namespace which already exists on create instead of failing, its name and description are updated to match this resource. Defaults to the provider `adopt_existing` setting.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -86,18 +109,19 @@ func (r *NamespaceResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*nacos.Client)
+	providerData, ok := req.ProviderData.(*NacosProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *nacos.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.adoptExisting = providerData.AdoptExisting
 }
 
 func (r *NamespaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -118,11 +142,12 @@ func (r *NamespaceResource) Create(ctx context.Context, req resource.CreateReque
 	tflog.Debug(ctx, "creating namespace", map[string]any{"id": data.NamespaceID.ValueString()})
 
 	config, err := r.client.GetNamespace(ctx, opts.ID)
-	if err == nil && config != nil {
+	adopted := err == nil && config != nil
+	if adopted && !AdoptExisting(data.AdoptExisting, r.adoptExisting) {
 		resp.Diagnostics.AddError(
 			"Namespace already exists",
 			fmt.Sprintf("A namespace with namespace_id=%s already exists. "+
-				"Run `terraform import nacos_namespace.example %s` to manage it.", opts.ID, opts.ID),
+				"Run `terraform import nacos_namespace.example %s` or set adopt_existing to manage it.", opts.ID, opts.ID),
 		)
 		return
 	}
@@ -134,7 +159,11 @@ func (r *NamespaceResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	err = r.client.CreateNamespace(ctx, opts)
+	if adopted {
+		err = r.client.UpdateNamespace(ctx, opts)
+	} else {
+		err = r.client.CreateNamespace(ctx, opts)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create namespace",
@@ -142,6 +171,13 @@ func (r *NamespaceResource) Create(ctx context.Context, req resource.CreateReque
 		)
 		return
 	}
+	if adopted {
+		resp.Diagnostics.AddWarning(
+			"Adopted existing namespace",
+			fmt.Sprintf("The existing namespace with namespace_id=%s (name %q) is now managed by Terraform, "+
+				"its name and description were updated to match the configuration.", opts.ID, config.Name),
+		)
+	}
 
 	data.ID = data.NamespaceID
 
@@ -219,6 +255,15 @@ func (r *NamespaceResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	if data.DeletionPolicy.ValueString() == DeletionPolicyRetain {
+		tflog.Warn(ctx, "retaining namespace", map[string]any{"id": data.ID.ValueString()})
+		resp.Diagnostics.AddWarning(
+			"Namespace retained",
+			fmt.Sprintf("The namespace with namespace_id=%s was removed from Terraform state but not deleted from Nacos because deletion_policy is RETAIN.", data.ID.ValueString()),
+		)
+		return
+	}
+
 	err := r.client.DeleteNamespace(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
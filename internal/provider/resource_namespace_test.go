@@ -1,13 +1,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
 )
 
 func testAccNamespaceSourceConfig(namespaceId, name, description string) string {
@@ -87,3 +90,77 @@ func TestAccNamespaceResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccNamespaceResource_adoptExisting(t *testing.T) {
+	resourceName := "nacos_namespace.test"
+	namespaceId := "test-adopt-namespace-id"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					err := testClient.CreateNamespace(context.Background(), &nacos.NsOpts{ID: namespaceId, Name: "created-in-console"})
+					if err != nil {
+						t.Fatalf("Error creating namespace: %s", err)
+					}
+				},
+				Config: fmt.Sprintf(`
+resource "nacos_namespace" "test" {
+  namespace_id   = "%s"
+  name           = "adopted"
+  description    = "Adopted by terraform"
+  adopt_existing = true
+}
+`, namespaceId),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("name"),
+						knownvalue.StringExact("adopted"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccNamespaceResource_retain(t *testing.T) {
+	resourceName := "nacos_namespace.test"
+	namespaceId := "test-retain-namespace-id"
+	t.Cleanup(func() {
+		if testClient != nil {
+			_ = testClient.DeleteNamespace(context.Background(), namespaceId)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if _, err := testClient.GetNamespace(context.Background(), namespaceId); err != nil {
+				return fmt.Errorf("expected namespace to be retained: %w", err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "nacos_namespace" "test" {
+  namespace_id    = "%s"
+  name            = "retained"
+  deletion_policy = "RETAIN"
+}
+`, namespaceId),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("deletion_policy"),
+						knownvalue.StringExact("RETAIN"),
+					),
+				},
+			},
+		},
+	})
+}
@@ -28,13 +28,16 @@ func NewRoleResource() resource.Resource {
 // RoleResource defines the resource implementation.
 type RoleResource struct {
 	client *nacos.Client
+	// adoptExisting is the provider default of adopt_existing.
+	adoptExisting bool
 }
 
 // RoleResourceModel describes the resource data model.
 type RoleResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	Name     types.String `tfsdk:"name"`
-	Username types.String `tfsdk:"username"`
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Username      types.String `tfsdk:"username"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
 }
 
 func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -68,6 +71,10 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over a role binding which already exists on create instead of failing. Defaults to the provider `adopt_existing` setting.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -78,18 +85,19 @@ func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*nacos.Client)
+	providerData, ok := req.ProviderData.(*NacosProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *nacos.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.adoptExisting = providerData.AdoptExisting
 }
 
 func BuildRoleID(name, username string) string {
@@ -119,11 +127,12 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	role, err := r.client.GetRole(ctx, name, username)
 	id := BuildRoleID(name, username)
-	if err == nil && role != nil {
+	adopted := err == nil && role != nil
+	if adopted && !AdoptExisting(data.AdoptExisting, r.adoptExisting) {
 		resp.Diagnostics.AddError(
 			"Role already exists",
 			fmt.Sprintf("A role with name=%s,username=%s already exists. "+
-				"Run `terraform import nacos_role.example %s` to manage it.", name, username, id),
+				"Run `terraform import nacos_role.example %s` or set adopt_existing to manage it.", name, username, id),
 		)
 		return
 	}
@@ -135,8 +144,12 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	err = r.client.CreateRole(ctx, name, username)
-	if err != nil {
+	if adopted {
+		resp.Diagnostics.AddWarning(
+			"Adopted existing role",
+			fmt.Sprintf("The existing role with name=%s,username=%s is now managed by Terraform.", name, username),
+		)
+	} else if err = r.client.CreateRole(ctx, name, username); err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create role",
 			err.Error(),
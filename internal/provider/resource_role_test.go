@@ -1,11 +1,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
@@ -88,3 +90,61 @@ func TestAccRoleResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccRoleResource_adoptExisting(t *testing.T) {
+	resourceName := "nacos_role.test"
+	username := "tf-adopt-role-user"
+	name := "tf-adopt-role"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					ctx := context.Background()
+					if err := testClient.CreateUser(ctx, username, "123456"); err != nil {
+						t.Fatalf("Error creating user: %s", err)
+					}
+					t.Cleanup(func() {
+						if err := testClient.DeleteUser(ctx, username); err != nil {
+							t.Errorf("Error deleting user: %s", err)
+						}
+					})
+					if err := testClient.CreateRole(ctx, name, username); err != nil {
+						t.Fatalf("Error creating role: %s", err)
+					}
+				},
+				Config: fmt.Sprintf(`
+resource "nacos_role" "test" {
+  name           = "%s"
+  username       = "%s"
+  adopt_existing = true
+}
+`, name, username),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(fmt.Sprintf("%s:%s", name, username)),
+					),
+				},
+			},
+			// The adopted role is read back without changes
+			{
+				Config: fmt.Sprintf(`
+resource "nacos_role" "test" {
+  name           = "%s"
+  username       = "%s"
+  adopt_existing = true
+}
+`, name, username),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
@@ -26,13 +26,17 @@ func NewUserResource() resource.Resource {
 // UserResource defines the resource implementation.
 type UserResource struct {
 	client *nacos.Client
+	api    *NacosAPI
+	// adoptExisting is the provider default of adopt_existing.
+	adoptExisting bool
 }
 
 // UserResourceModel describes the resource data model.
 type UserResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	ID            types.String `tfsdk:"id"`
+	Username      types.String `tfsdk:"username"`
+	Password      types.String `tfsdk:"password"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
 }
 
 func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -67,6 +71,10 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over a user which already exists on create instead of failing, its password is reset to `password`. Defaults to the provider `adopt_existing` setting.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -77,18 +85,20 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*nacos.Client)
+	providerData, ok := req.ProviderData.(*NacosProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *nacos.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.NacosProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.api = providerData.API
+	r.adoptExisting = providerData.AdoptExisting
 }
 
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -105,11 +115,12 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	tflog.Debug(ctx, "creating user", map[string]any{"username": username})
 
 	user, err := r.client.GetUser(ctx, username)
-	if err == nil && user != nil {
+	adopted := err == nil && user != nil
+	if adopted && !AdoptExisting(data.AdoptExisting, r.adoptExisting) {
 		resp.Diagnostics.AddError(
 			"User already exists",
 			fmt.Sprintf("A user with username=%s already exists. "+
-				"Run `terraform import nacos_user.example %s` to manage it.", username, username),
+				"Run `terraform import nacos_user.example %s` or set adopt_existing to manage it.", username, username),
 		)
 		return
 	}
@@ -121,7 +132,11 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	err = r.client.CreateUser(ctx, username, password)
+	if adopted {
+		err = r.api.UpdateUserPassword(ctx, username, password)
+	} else {
+		err = r.client.CreateUser(ctx, username, password)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create user",
@@ -129,6 +144,12 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		)
 		return
 	}
+	if adopted {
+		resp.Diagnostics.AddWarning(
+			"Adopted existing user",
+			fmt.Sprintf("The existing user with username=%s is now managed by Terraform, its password was reset to match the configuration.", username),
+		)
+	}
 
 	// Set ID before saving state - this is crucial for proper identity handling
 	data.ID = data.Username
@@ -1,13 +1,17 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/joelee2012/go-nacos"
 )
 
 func testAccUserSourceConfig(username, password string) string {
@@ -83,3 +87,59 @@ func TestAccUserResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccUserResource_adoptExisting(t *testing.T) {
+	resourceName := "nacos_user.test"
+	username := "tf-adopt-user"
+	password := "adopted-password"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := testClient.CreateUser(context.Background(), username, "created-in-console"); err != nil {
+						t.Fatalf("Error creating user: %s", err)
+					}
+				},
+				Config: fmt.Sprintf(`
+resource "nacos_user" "test" {
+  username       = "%s"
+  password       = "%s"
+  adopt_existing = true
+}
+`, username, password),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(username),
+					),
+					statecheck.ExpectKnownValue(
+						resourceName,
+						tfjsonpath.New("password"),
+						knownvalue.StringExact(password),
+					),
+				},
+				Check: testAccCheckUserPassword(username, password),
+			},
+		},
+	})
+}
+
+// testAccCheckUserPassword checks that the user can log in with the password,
+// i.e. that adopting the user reset its password.
+func testAccCheckUserPassword(username, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := nacos.NewClient(os.Getenv("NACOS_HOST"), username, password)
+		if err != nil {
+			return fmt.Errorf("error creating client for user %s: %w", username, err)
+		}
+		// Listing namespaces requires logging in.
+		if _, err := client.ListNamespace(context.Background()); err != nil {
+			return fmt.Errorf("user %s is unable to log in with the configured password: %w", username, err)
+		}
+		return nil
+	}
+}